@@ -0,0 +1,181 @@
+package dateparse
+
+import (
+	"runtime"
+	"sync"
+	"time"
+)
+
+// minBatchChunk is the fewest values worth handing to another goroutine.
+const minBatchChunk = 64
+
+// BatchResult holds the per value results of ParseMany, Times and Errors
+// are parallel to the input values.
+type BatchResult struct {
+	// Times parsed, zero where Errors has an error
+	Times []time.Time
+	// Errors per value, nil where it parsed
+	Errors []error
+	// Layouts counts how many values parsed with each layout (see
+	// Layout.String, epochs are counted by unit ie "epoch-ms")
+	Layouts map[string]int
+	// Failed is the number of values that did not parse
+	Failed int
+}
+
+// Parallel is an option for ParseMany, spreading a batch over up to n
+// goroutines (n < 1 uses GOMAXPROCS).  Small batches are parsed on the
+// calling goroutine regardless.  Other parse funcs ignore it.
+func Parallel(n int) ParserOption {
+	return func(p *parser) error {
+		if n < 1 {
+			n = runtime.GOMAXPROCS(0)
+		}
+		p.parallel = n
+		return nil
+	}
+}
+
+// ParseMany parses a batch of date strings, ie a whole column, with the
+// same rules as ParseAny.  Values of the same shape (digits, letters and
+// punctuation in the same places) usually share a layout, so once one is
+// detected the rest are parsed directly with time.Parse, falling back to
+// detection for any that don't fit.
+//
+//	res := dateparse.ParseMany(column, dateparse.Parallel(0))
+//	for i, err := range res.Errors {
+//		...
+//	}
+func ParseMany(values []string, opts ...ParserOption) *BatchResult {
+	return ParseManyIn(values, nil, opts...)
+}
+
+// ParseManyIn is ParseMany with a location, same rules as ParseIn.
+func ParseManyIn(values []string, loc *time.Location, opts ...ParserOption) *BatchResult {
+	b := &batch{
+		loc:    loc,
+		opts:   opts,
+		cache:  make(map[string]Layout),
+		layout: make([]string, len(values)),
+		res: &BatchResult{
+			Times:   make([]time.Time, len(values)),
+			Errors:  make([]error, len(values)),
+			Layouts: make(map[string]int),
+		},
+	}
+	// read the batch settings the options give
+	p := newParser("", loc, opts...)
+	b.preferMonthFirst = p.preferMonthFirst
+	b.strict = p.strict != 0
+
+	workers := p.parallel
+	if max := len(values) / minBatchChunk; workers > max {
+		workers = max
+	}
+	if workers <= 1 {
+		b.parseRange(values, 0, len(values))
+	} else {
+		var wg sync.WaitGroup
+		size := (len(values) + workers - 1) / workers
+		for start := 0; start < len(values); start += size {
+			end := start + size
+			if end > len(values) {
+				end = len(values)
+			}
+			wg.Add(1)
+			go func(start, end int) {
+				defer wg.Done()
+				b.parseRange(values, start, end)
+			}(start, end)
+		}
+		wg.Wait()
+	}
+
+	for i, err := range b.res.Errors {
+		if err != nil {
+			b.res.Failed++
+			continue
+		}
+		b.res.Layouts[b.layout[i]]++
+	}
+	return b.res
+}
+
+// batch is the state shared by the goroutines of one ParseMany
+type batch struct {
+	loc              *time.Location
+	opts             []ParserOption
+	preferMonthFirst bool
+	// strict batches check every value, time.Parse doesn't know the rules
+	strict bool
+
+	mu    sync.RWMutex
+	cache map[string]Layout // by valueShape
+
+	// per value, each index is only written by one goroutine
+	layout []string
+	res    *BatchResult
+}
+
+func (b *batch) parseRange(values []string, start, end int) {
+	for i := start; i < end; i++ {
+		b.res.Times[i], b.layout[i], b.res.Errors[i] = b.parse(values[i])
+	}
+}
+
+func (b *batch) parse(datestr string) (time.Time, string, error) {
+	shape := valueShape(datestr)
+	b.mu.RLock()
+	l, ok := b.cache[shape]
+	b.mu.RUnlock()
+	if ok {
+		if t, err := l.Parse(datestr, b.loc); err == nil {
+			return t, l.Layout, nil
+		}
+	}
+
+	p, err := parseTime(datestr, b.loc, b.opts...)
+	if err != nil {
+		return time.Time{}, "", err
+	}
+	t, err := p.parse()
+	if err != nil {
+		return time.Time{}, "", err
+	}
+	l = p.layout(datestr)
+	if !b.strict && cacheable(l, b.preferMonthFirst) {
+		b.mu.Lock()
+		b.cache[shape] = l
+		b.mu.Unlock()
+	}
+	return t, l.String(), nil
+}
+
+// cacheable is true for layouts that time.Parse can apply directly to
+// other values with the same result the parser would give: no rewriting
+// of the input, and not a RetryAmbiguousDateWithSwap swap, which must only
+// apply to the values that needed it.
+func cacheable(l Layout, preferMonthFirst bool) bool {
+	if l.Kind != LayoutGo || l.Flags&LayoutRewritten != 0 {
+		return false
+	}
+	swapped := l.Flags&LayoutDayFirst != 0 == preferMonthFirst
+	return l.Flags&LayoutAmbiguous == 0 || !swapped
+}
+
+// valueShape is the date string with ascii digits replaced by 0, and
+// letters by A or a, so "Mar 3 2014" and "Jan 9 2020" share a shape.
+func valueShape(datestr string) string {
+	shape := []byte(datestr)
+	for i, c := range shape {
+		switch {
+		case c >= '0' && c <= '9':
+			shape[i] = '0'
+		case c >= 'a' && c <= 'z':
+			shape[i] = 'a'
+		case c >= 'A' && c <= 'Z':
+			shape[i] = 'A'
+		}
+	}
+	return string(shape)
+}
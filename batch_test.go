@@ -0,0 +1,111 @@
+package dateparse
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMany(t *testing.T) {
+	time.Local = time.UTC
+
+	values := []string{
+		"2014-04-26 17:24:37",
+		"2014-04-27 05:04:00",
+		"not a date",
+		"1332151919",
+		"Mon Jan  2 15:04:05 MST 2006",
+		"2014-13-27 05:04:00",
+	}
+	res := ParseMany(values)
+	assert.Equal(t, 2, res.Failed)
+	assert.Equal(t, map[string]int{
+		"2006-01-02 15:04:05":      2,
+		"epoch-s":                  1,
+		"Jan  2 15:04:05 MST 2006": 1,
+	}, res.Layouts)
+	for i, v := range values {
+		want, err := ParseAny(v)
+		assert.Equal(t, err != nil, res.Errors[i] != nil, v)
+		assert.Equal(t, want, res.Times[i], v)
+	}
+}
+
+func TestParseManySwap(t *testing.T) {
+	time.Local = time.UTC
+
+	// a swapped layout must not be re-used for the values that follow
+	values := []string{"13/02/2020", "02/03/2020", "03/14/2020", "14/03/2020"}
+	res := ParseMany(values, RetryAmbiguousDateWithSwap(true))
+	assert.Equal(t, 0, res.Failed)
+	for i, v := range values {
+		want, err := ParseAny(v, RetryAmbiguousDateWithSwap(true))
+		assert.Equal(t, nil, err)
+		assert.Equal(t, want, res.Times[i], v)
+	}
+	assert.Equal(t, map[string]int{"02/01/2006": 2, "01/02/2006": 2}, res.Layouts)
+
+	res = ParseMany(values, PreferMonthFirst(false))
+	assert.Equal(t, 1, res.Failed)
+	assert.NotEqual(t, nil, res.Errors[2])
+	assert.Equal(t, "2020-03-02 00:00:00 +0000 UTC", fmt.Sprintf("%v", res.Times[1]))
+}
+
+func TestParseManyParallel(t *testing.T) {
+	time.Local = time.UTC
+	denverLoc, err := time.LoadLocation("America/Denver")
+	assert.Equal(t, nil, err)
+
+	values := make([]string, 1000)
+	for i := range values {
+		tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC).Add(time.Duration(i) * time.Hour)
+		switch i % 3 {
+		case 0:
+			values[i] = tm.Format("2006-01-02 15:04:05")
+		case 1:
+			values[i] = tm.Format("Jan 2, 2006 3:04pm")
+		default:
+			values[i] = fmt.Sprintf("bad %d", i)
+		}
+	}
+	res := ParseManyIn(values, denverLoc, Parallel(4))
+	assert.Equal(t, 333, res.Failed)
+	parsed := 0
+	for _, n := range res.Layouts {
+		parsed += n
+	}
+	assert.Equal(t, 667, parsed)
+	assert.Equal(t, 334, res.Layouts["2006-01-02 15:04:05"])
+	for i, v := range values {
+		want, err := ParseIn(v, denverLoc)
+		assert.Equal(t, err != nil, res.Errors[i] != nil, v)
+		assert.Equal(t, want, res.Times[i], v)
+	}
+}
+
+func TestParseManyZulu(t *testing.T) {
+	denverLoc, err := time.LoadLocation("America/Denver")
+	assert.Equal(t, nil, err)
+
+	// the cached layout reads a Z as UTC, like the parser
+	values := []string{
+		"2017-07-19T03:22:00.123Z",
+		"2017-07-19T03:22:00.123Z",
+		"2017-07-19T03:22:00Z",
+		"2017-07-20T03:22:00Z",
+		"2017-07-19 03:22:00",
+		"2017-07-20 03:22:00",
+	}
+	res := ParseManyIn(values, denverLoc)
+	assert.Equal(t, 0, res.Failed)
+	for i, v := range values {
+		want, err := ParseIn(v, denverLoc)
+		assert.Equal(t, nil, err, v)
+		assert.Equal(t, want, res.Times[i], v)
+	}
+	assert.Equal(t, "2017-07-19 03:22:00.123 +0000 UTC", res.Times[1].String())
+	assert.Equal(t, "2017-07-20 03:22:00 +0000 UTC", res.Times[3].String())
+	assert.Equal(t, "2017-07-20 03:22:00 -0600 MDT", res.Times[5].String())
+}
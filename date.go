@@ -0,0 +1,192 @@
+package dateparse
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// ErrTimeOfDay is returned by ParseDate, with a Strict option, for
+// dates with a time of day.
+var ErrTimeOfDay = fmt.Errorf("This date has a time of day")
+
+// Date is a calendar date without a time or location, ie a birthday or
+// due date, see ParseDate.  Converting it to a time.Time is explicit,
+// with In, so it can't shift a day across zones.
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// ParseDate parses an unknown date format into the calendar date it
+// names, as written: "2020-01-02T23:00:00-07:00" is January 2nd, not
+// the 3rd it is in UTC.  Epochs are dates in UTC.  Any time of day is
+// dropped, or with a Strict option fails with ErrTimeOfDay.  Zones don't
+// change the date, so StrictZone guesses are not checked.
+//
+//	d, err := dateparse.ParseDate("March 3rd, 1990")
+//	// d.String() = "1990-03-03"
+func ParseDate(datestr string, opts ...ParserOption) (Date, error) {
+	p, err := parseTime(datestr, nil, opts...)
+	if err != nil {
+		return Date{}, err
+	}
+	// the date as written doesn't depend on a zone
+	strict := p.strict
+	p.strict &^= StrictZone
+	t, err := p.parse()
+	if err != nil {
+		return Date{}, err
+	}
+	if strict != 0 && p.hasTimeOfDay() {
+		return Date{}, ErrTimeOfDay
+	}
+	if p.t != nil {
+		// an epoch is an instant, not a date as written
+		t = t.UTC()
+	}
+	return DateOf(t), nil
+}
+
+// hasTimeOfDay is true if the parsed layout has a clock, or is an epoch.
+func (p *parser) hasTimeOfDay() bool {
+	if p.t != nil {
+		return true
+	}
+	for _, c := range layoutChunks(string(p.format)) {
+		switch elemField(c.elem) {
+		case "hour", "minute", "second", "fraction", "ampm":
+			return true
+		}
+	}
+	return false
+}
+
+// DateOf is the calendar date of t, in t's location.
+func DateOf(t time.Time) Date {
+	y, m, d := t.Date()
+	return Date{Year: y, Month: m, Day: d}
+}
+
+// In is the start of the day d in loc (nil is UTC), usually midnight,
+// but 01:00 where a daylight saving change skips midnight.
+func (d Date) In(loc *time.Location) time.Time {
+	t, _ := TimeOfDay{}.On(time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, time.UTC), loc)
+	return t
+}
+
+// IsZero is true for the zero Date, which is not a valid date.
+func (d Date) IsZero() bool {
+	return d == Date{}
+}
+
+// String is the date in ISO 8601 form, ie "2006-01-02".
+func (d Date) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, int(d.Month), d.Day)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface, so json
+// writes it as a "2006-01-02" string.
+func (d Date) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface,
+// accepting any format ParseDate does, with DefaultConfig's Options.
+func (d *Date) UnmarshalText(data []byte) error {
+	v, err := ParseDate(string(data), DefaultConfig.Options...)
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// Scan implements the sql.Scanner interface, accepting DATE columns
+// (time.Time, its date is used as is) and strings in any format.  Use
+// NullDate for columns that may be NULL.
+func (d *Date) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case time.Time:
+		*d = DateOf(v)
+		return nil
+	case string:
+		return d.UnmarshalText([]byte(v))
+	case []byte:
+		return d.UnmarshalText(v)
+	case nil:
+		return fmt.Errorf("can not scan NULL into dateparse.Date, use dateparse.NullDate")
+	}
+	return fmt.Errorf("can not scan %T into a date", src)
+}
+
+// Value implements the driver.Valuer interface, as a "2006-01-02"
+// string, which databases read into DATE columns without a zone.
+func (d Date) Value() (driver.Value, error) {
+	return d.String(), nil
+}
+
+// NullDate is a Date that may be NULL, empty strings are read as NULL as
+// well, and it is written as json null when not Valid.
+type NullDate struct {
+	Date  Date
+	Valid bool // Valid is true if Date is not NULL
+}
+
+// Scan implements the sql.Scanner interface.
+func (n *NullDate) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		n.Date, n.Valid = Date{}, false
+		return nil
+	case string:
+		if isBlank([]byte(v)) {
+			n.Date, n.Valid = Date{}, false
+			return nil
+		}
+	case []byte:
+		if isBlank(v) {
+			n.Date, n.Valid = Date{}, false
+			return nil
+		}
+	}
+	if err := n.Date.Scan(src); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (n NullDate) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Date.Value()
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, null and ""
+// are not Valid.
+func (n *NullDate) UnmarshalJSON(data []byte) error {
+	s := bytes.TrimSpace(data)
+	if string(s) == "null" || string(s) == `""` {
+		n.Date, n.Valid = Date{}, false
+		return nil
+	}
+	if err := json.Unmarshal(s, &n.Date); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (n NullDate) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return []byte(`"` + n.Date.String() + `"`), nil
+}
@@ -0,0 +1,151 @@
+package dateparse
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// make sure we implement the interfaces
+var (
+	_ sql.Scanner   = (*Date)(nil)
+	_ driver.Valuer = Date{}
+	_ sql.Scanner   = (*NullDate)(nil)
+	_ driver.Valuer = NullDate{}
+)
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		in  string
+		out string
+	}{
+		{"2020-01-02", "2020-01-02"},
+		{"March 3rd, 1990", "1990-03-03"},
+		{"02/01/2020", "2020-02-01"},
+		{"the twenty-first of June 2021", "2021-06-21"},
+		// as written, not as in UTC
+		{"2020-01-02T23:00:00-07:00", "2020-01-02"},
+		{"2020-01-02T01:00:00+09:00", "2020-01-02"},
+		{"1332151919", "2012-03-19"},
+		{"0990-01-02", "0990-01-02"},
+	}
+	for _, tc := range tests {
+		d, err := ParseDate(tc.in)
+		assert.Equal(t, nil, err, tc.in)
+		assert.Equal(t, tc.out, d.String(), tc.in)
+	}
+
+	d, err := ParseDate("02/01/2020", PreferMonthFirst(false))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, Date{2020, time.January, 2}, d)
+
+	_, err = ParseDate("not a date")
+	assert.NotEqual(t, nil, err)
+
+	// strict rejects a time of day, as well as its other guesses
+	for _, in := range []string{"2020-01-02 10:00", "2020-01-02T00:00:00Z", "Jan 2, 2020 at noon", "1332151919"} {
+		_, err = ParseDate(in, Strict(StrictTwoDigitYear))
+		assert.Equal(t, ErrTimeOfDay, err, in)
+	}
+	d, err = ParseDate("Jan 2, 2020", Strict(StrictTwoDigitYear))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2020-01-02", d.String())
+	_, err = ParseDate("1/2/20", Strict(StrictTwoDigitYear))
+	assert.Equal(t, ErrTwoDigitYear, err)
+
+	// a date needs no zone, any strictness checks the time of day first
+	d, err = ParseDate("2020-01-02", Strict(StrictAll))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2020-01-02", d.String())
+	_, err = ParseDate("2020-01-02", Strict(StrictNoZone))
+	assert.Equal(t, nil, err)
+	_, err = ParseDate("2020-01-02 10:00", Strict(StrictAll))
+	assert.Equal(t, ErrTimeOfDay, err)
+	_, err = ParseDate("1/2/20", Strict(StrictAll))
+	assert.Equal(t, ErrAmbiguousMMDD, err)
+}
+
+func TestParseDateEpochLocal(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	assert.Equal(t, nil, err)
+	local := time.Local
+	time.Local = tokyo
+	defer func() { time.Local = local }()
+
+	// 2012-03-20 in Tokyo, the date is in UTC
+	d, err := ParseDate("1332198000")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2012-03-19", d.String())
+}
+
+func TestDateIn(t *testing.T) {
+	denver, err := time.LoadLocation("America/Denver")
+	assert.Equal(t, nil, err)
+	d := Date{2020, time.March, 8}
+	assert.Equal(t, "2020-03-08T00:00:00Z", d.In(nil).Format(time.RFC3339))
+	assert.Equal(t, "2020-03-08T00:00:00-07:00", d.In(denver).Format(time.RFC3339))
+	assert.Equal(t, d, DateOf(d.In(denver)))
+
+	// no midnight when clocks go forward at 00:00
+	havana, err := time.LoadLocation("America/Havana")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2020-03-08T01:00:00-04:00", d.In(havana).Format(time.RFC3339))
+
+	assert.Equal(t, true, Date{}.IsZero())
+	assert.Equal(t, false, d.IsZero())
+}
+
+func TestDateSQL(t *testing.T) {
+	var d Date
+	assert.Equal(t, nil, d.Scan(time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, Date{2020, time.January, 2}, d)
+	assert.Equal(t, nil, d.Scan("Jan 3, 2020"))
+	assert.Equal(t, Date{2020, time.January, 3}, d)
+	assert.Equal(t, nil, d.Scan([]byte("2020-01-04")))
+	assert.Equal(t, Date{2020, time.January, 4}, d)
+	assert.NotEqual(t, nil, d.Scan(nil))
+	assert.NotEqual(t, nil, d.Scan(3.5))
+	v, err := d.Value()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2020-01-04", v)
+
+	var n NullDate
+	assert.Equal(t, nil, n.Scan(nil))
+	assert.Equal(t, false, n.Valid)
+	assert.Equal(t, nil, n.Scan(" "))
+	assert.Equal(t, false, n.Valid)
+	v, err = n.Value()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, nil, v)
+	assert.Equal(t, nil, n.Scan("2020-01-02"))
+	assert.Equal(t, NullDate{Date{2020, time.January, 2}, true}, n)
+	v, err = n.Value()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2020-01-02", v)
+}
+
+func TestDateJSON(t *testing.T) {
+	var payload struct {
+		Born Date     `json:"born"`
+		Due  NullDate `json:"due"`
+		Paid NullDate `json:"paid"`
+	}
+	err := json.Unmarshal([]byte(`{"born":"March 3rd, 1990","due":"2020-01-02T23:00:00-07:00","paid":null}`), &payload)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, Date{1990, time.March, 3}, payload.Born)
+	assert.Equal(t, NullDate{Date{2020, time.January, 2}, true}, payload.Due)
+	assert.Equal(t, false, payload.Paid.Valid)
+
+	b, err := json.Marshal(payload)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, `{"born":"1990-03-03","due":"2020-01-02","paid":null}`, string(b))
+
+	assert.NotEqual(t, nil, json.Unmarshal([]byte(`"not a date"`), &payload.Born))
+	assert.NotEqual(t, nil, json.Unmarshal([]byte(`"not a date"`), &payload.Due))
+	assert.Equal(t, nil, json.Unmarshal([]byte(`""`), &payload.Due))
+	assert.Equal(t, false, payload.Due.Valid)
+}
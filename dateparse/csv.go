@@ -0,0 +1,401 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/araddon/dateparse"
+)
+
+// csvDelimiters are the delimiters sniffed for, in order of preference on
+// a tie
+var csvDelimiters = []rune{',', '\t', ';', '|'}
+
+// csvColumns normalizes date columns of a csv/tsv file, each column is
+// read in the layout most of its first values are in, and consistently
+// month first or day first, whichever more of them parse with.
+//
+//	dateparse csv --column created_at --to rfc3339 in.csv > out.csv
+func csvColumns(args []string) error {
+	fs := flag.NewFlagSet("csv", flag.ExitOnError)
+	var cfg parseConfig
+	cfg.register(fs)
+	columns := fs.String("column", "", "comma separated column `names` or 1 based indexes to normalize")
+	to := fs.String("to", "rfc3339", outputFormatUsage)
+	delimiter := fs.String("delimiter", "", "field delimiter, sniffed from the first line when empty")
+	noHeader := fs.Bool("no-header", false, "the first row is data, not column names")
+	onError := fs.String("on-error", "keep", "what to do with rows whose dates don't parse: `skip|keep|fail`")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "usage: dateparse csv --column name[,name] [flags] [file]\n\nReads the file or stdin, writes csv to stdout.\n\n")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	if *columns == "" {
+		return fmt.Errorf("--column is required")
+	}
+	switch *onError {
+	case "skip", "keep", "fail":
+	default:
+		return fmt.Errorf("--on-error must be skip, keep or fail, not %q", *onError)
+	}
+	if err := cfg.load(); err != nil {
+		return err
+	}
+	name := "stdin"
+	var in io.Reader = os.Stdin
+	switch fs.NArg() {
+	case 0:
+	case 1:
+		if name = fs.Arg(0); name != "-" {
+			f, err := os.Open(name)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			in = f
+		}
+	default:
+		return fmt.Errorf("csv takes one file")
+	}
+
+	br := bufio.NewReader(in)
+	var comma rune
+	if *delimiter != "" {
+		d := []rune(strings.Replace(*delimiter, `\t`, "\t", 1))
+		comma = d[0]
+	} else {
+		first, _ := br.Peek(br.Size())
+		if i := bytes.IndexByte(first, '\n'); i >= 0 {
+			first = first[:i]
+		}
+		comma = sniffDelimiter(string(first))
+	}
+	cn := &csvNormalizer{
+		cfg:     &cfg,
+		columns: *columns,
+		header:  !*noHeader,
+		comma:   comma,
+		out:     outputFormat(*to),
+		onError: *onError,
+		name:    name,
+		errs:    os.Stderr,
+	}
+	w := csv.NewWriter(os.Stdout)
+	w.Comma = comma
+	return cn.run(br, w)
+}
+
+// csvSampleRows are read ahead to settle each column's layout and
+// day/month order, the rest of the file is streamed in them
+const csvSampleRows = 1000
+
+// csvNormalizer rewrites the date columns of the records of a csv reader.
+type csvNormalizer struct {
+	cfg *parseConfig
+	// columns is the --column list, see csvColumnIndexes
+	columns string
+	// header is true if the first record is column names
+	header  bool
+	comma   rune
+	out     func(time.Time) string
+	onError string
+	// name of the input, for errors
+	name string
+	// errs gets the rows that fail, unless onError is fail
+	errs io.Writer
+}
+
+// csvRecord is a record with the line each date column's field starts
+// on, which quoted newlines make differ from the record number
+type csvRecord struct {
+	fields []string
+	lines  []int
+}
+
+func (cn *csvNormalizer) run(in io.Reader, w *csv.Writer) error {
+	lines := &lineReader{r: bufio.NewReader(in), atStart: true}
+	r := csv.NewReader(lines)
+	r.Comma = cn.comma
+	r.FieldsPerRecord = -1
+	var header []string
+	if cn.header {
+		fields, err := r.Read()
+		if err != nil && err != io.EOF {
+			return fmt.Errorf("%s: %v", cn.name, err)
+		}
+		header = fields
+	}
+	cols, err := csvColumnIndexes(cn.columns, header)
+	if err != nil {
+		return err
+	}
+	if header != nil {
+		w.Write(header)
+	}
+
+	var sample []csvRecord
+	for len(sample) < csvSampleRows {
+		rec, err := cn.read(r, lines, cols)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		sample = append(sample, rec)
+	}
+	columns := make([]*csvColumn, len(cols))
+	for j, col := range cols {
+		values := make([]string, len(sample))
+		for i, rec := range sample {
+			if col < len(rec.fields) {
+				values[i] = strings.TrimSpace(rec.fields[col])
+			}
+		}
+		columns[j] = newCsvColumn(cn.cfg.columnConfig(values), values)
+	}
+
+	write := func(rec csvRecord) error {
+		keep, err := cn.normalize(rec, cols, columns, header)
+		if err != nil {
+			return err
+		}
+		if keep {
+			w.Write(rec.fields)
+		}
+		return nil
+	}
+	for _, rec := range sample {
+		if err := write(rec); err != nil {
+			return err
+		}
+	}
+	for {
+		rec, err := cn.read(r, lines, cols)
+		if err == io.EOF {
+			break
+		}
+		if err == nil {
+			err = write(rec)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// read the next record, noting the lines of its date columns, lines is
+// the input of r
+func (cn *csvNormalizer) read(r *csv.Reader, lines *lineReader, cols []int) (csvRecord, error) {
+	fields, err := r.Read()
+	if err == io.EOF {
+		return csvRecord{}, err
+	}
+	if err != nil {
+		// a csv.ParseError, which has the line
+		return csvRecord{}, fmt.Errorf("%s: %v", cn.name, err)
+	}
+	// r has read up to the record's last line, it starts as many lines
+	// before as it has quoted newlines
+	start := lines.lines
+	for _, f := range fields {
+		start -= strings.Count(f, "\n")
+	}
+	rec := csvRecord{fields: fields, lines: make([]int, len(cols))}
+	for j, col := range cols {
+		if col < len(fields) {
+			rec.lines[j] = start
+			for _, f := range fields[:col] {
+				rec.lines[j] += strings.Count(f, "\n")
+			}
+		}
+	}
+	return rec, nil
+}
+
+// lineReader hands out its input a line at a time, so a csv.Reader of it
+// has read no further than the record it returned, and lines counts the
+// lines that took (csv.Reader.FieldPos is go 1.17+)
+type lineReader struct {
+	r       *bufio.Reader
+	pending []byte
+	// lines begun
+	lines   int
+	atStart bool
+}
+
+func (lr *lineReader) Read(p []byte) (int, error) {
+	if len(lr.pending) == 0 {
+		line, err := lr.r.ReadSlice('\n')
+		if len(line) == 0 {
+			return 0, err
+		}
+		if lr.atStart {
+			lr.lines++
+		}
+		// a line longer than the buffer comes in parts
+		lr.atStart = line[len(line)-1] == '\n'
+		lr.pending = line
+	}
+	n := copy(p, lr.pending)
+	lr.pending = lr.pending[n:]
+	return n, nil
+}
+
+// normalize rewrites the date columns of rec in place, cols[j] is parsed
+// as columns[j].  keep is false if the record should be skipped.
+func (cn *csvNormalizer) normalize(rec csvRecord, cols []int, columns []*csvColumn, header []string) (keep bool, err error) {
+	keep = true
+	for j, col := range cols {
+		if col >= len(rec.fields) {
+			continue
+		}
+		value := strings.TrimSpace(rec.fields[col])
+		if value == "" {
+			// empty cells are left as is
+			continue
+		}
+		t, err := columns[j].parse(value)
+		if err != nil {
+			err = fmt.Errorf("%s:%d: column %s: %v", cn.name, rec.lines[j], csvColumnName(col, header), err)
+			if cn.onError == "fail" {
+				return false, err
+			}
+			fmt.Fprintln(cn.errs, err)
+			keep = cn.onError != "skip"
+			continue
+		}
+		rec.fields[col] = cn.out(t)
+	}
+	return keep, nil
+}
+
+// columnConfig is the config to read a column with, month first or day
+// first, whichever fails less on its values.  With equal failures the
+// data doesn't settle the order, so the --day-first setting is used, or
+// with --strict the ambiguous values fail.
+func (c *parseConfig) columnConfig(values []string) *parseConfig {
+	// the whole column is read in one order, so no per value swaps
+	one := *c
+	one.RetrySwap = false
+	other := one
+	other.DayFirst = !c.DayFirst
+	res := dateparse.ParseManyIn(values, c.loc, one.options()...)
+	alt := dateparse.ParseManyIn(values, c.loc, other.options()...)
+	if alt.Failed < res.Failed {
+		other.Strict = false
+		return &other
+	}
+	one.Strict = c.Strict && alt.Failed == res.Failed
+	return &one
+}
+
+// csvColumn is how a date column is read, in its layout where values are
+// in it, or per the column's config
+type csvColumn struct {
+	cfg *parseConfig
+	// layout is the go layout most sampled values parsed in, nil when
+	// there is none or the config is strict (time.Parse doesn't know the
+	// rules)
+	layout *dateparse.Layout
+}
+
+// newCsvColumn reads a column per cfg, and in the layout of the most of
+// its sampled values
+func newCsvColumn(cfg *parseConfig, values []string) *csvColumn {
+	col := &csvColumn{cfg: cfg}
+	if cfg.Strict || cfg.Strictness != 0 {
+		return col
+	}
+	counts := make(map[dateparse.Layout]int)
+	best := 0
+	for _, value := range values {
+		if value == "" {
+			continue
+		}
+		_, l, err := cfg.parseLayout(value)
+		// epochs are read by their digit count, not a layout
+		if err != nil || l.Kind != dateparse.LayoutGo {
+			continue
+		}
+		counts[l]++
+		if counts[l] > best {
+			best = counts[l]
+			layout := l
+			col.layout = &layout
+		}
+	}
+	return col
+}
+
+// parse value in the column's layout, or any other format in the column's
+// day/month order
+func (col *csvColumn) parse(value string) (time.Time, error) {
+	if col.layout != nil {
+		if t, err := col.layout.Parse(value, col.cfg.loc); err == nil {
+			return t, nil
+		}
+	}
+	return col.cfg.parse(value)
+}
+
+// sniffDelimiter picks the delimiter appearing most often (outside quotes)
+// in the line
+func sniffDelimiter(line string) rune {
+	counts := make(map[rune]int)
+	quoted := false
+	for _, r := range line {
+		if r == '"' {
+			quoted = !quoted
+		} else if !quoted {
+			counts[r]++
+		}
+	}
+	best := csvDelimiters[0]
+	for _, d := range csvDelimiters {
+		if counts[d] > counts[best] {
+			best = d
+		}
+	}
+	return best
+}
+
+// csvColumnIndexes resolves the --column list, names are matched against
+// the header first, then numbers are taken as 1 based indexes.
+func csvColumnIndexes(spec string, header []string) ([]int, error) {
+	var cols []int
+nextColumn:
+	for _, name := range strings.Split(spec, ",") {
+		name = strings.TrimSpace(name)
+		for i, h := range header {
+			if strings.TrimSpace(h) == name {
+				cols = append(cols, i)
+				continue nextColumn
+			}
+		}
+		n, err := strconv.Atoi(name)
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("no column %q", name)
+		}
+		cols = append(cols, n-1)
+	}
+	return cols, nil
+}
+
+func csvColumnName(col int, header []string) string {
+	if col < len(header) {
+		return header[col]
+	}
+	return strconv.Itoa(col + 1)
+}
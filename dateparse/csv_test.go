@@ -0,0 +1,188 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/araddon/dateparse"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSniffDelimiter(t *testing.T) {
+	for _, tc := range []struct {
+		line string
+		want rune
+	}{
+		{"id,created_at,name", ','},
+		{"id\tcreated_at\tname", '\t'},
+		{"id;created_at;name", ';'},
+		{"id|created_at|name", '|'},
+		// commas inside quotes don't count
+		{`"a,b,c";"d,e";f`, ';'},
+		// a tie is the first in csvDelimiters
+		{"a,b;c", ','},
+		{"created_at", ','},
+		{"", ','},
+	} {
+		assert.Equal(t, string(tc.want), string(sniffDelimiter(tc.line)), tc.line)
+	}
+}
+
+func TestCsvColumnIndexes(t *testing.T) {
+	header := []string{"id", " created_at ", "2", "updated_at"}
+	for _, tc := range []struct {
+		spec   string
+		header []string
+		want   []int
+		err    bool
+	}{
+		{spec: "created_at", header: header, want: []int{1}},
+		{spec: "created_at, updated_at", header: header, want: []int{1, 3}},
+		{spec: "1,4", header: header, want: []int{0, 3}},
+		// names win over indexes
+		{spec: "2", header: header, want: []int{2}},
+		{spec: "2", want: []int{1}},
+		{spec: "deleted_at", header: header, err: true},
+		{spec: "0", err: true},
+		{spec: "-1", err: true},
+	} {
+		cols, err := csvColumnIndexes(tc.spec, tc.header)
+		if tc.err {
+			assert.NotEqual(t, nil, err, tc.spec)
+			continue
+		}
+		assert.Equal(t, nil, err, tc.spec)
+		assert.Equal(t, tc.want, cols, tc.spec)
+	}
+}
+
+func TestColumnConfig(t *testing.T) {
+	for _, tc := range []struct {
+		values   []string
+		cfg      parseConfig
+		dayFirst bool
+		strict   bool
+	}{
+		// 13/02 only parses day first
+		{values: []string{"02/03/2020", "13/02/2020"}, dayFirst: true},
+		{values: []string{"02/13/2020", "02/03/2020"}, cfg: parseConfig{DayFirst: true}, dayFirst: false},
+		// nothing settles it, the flag does
+		{values: []string{"02/03/2020", ""}, dayFirst: false},
+		{values: []string{"02/03/2020"}, cfg: parseConfig{DayFirst: true}, dayFirst: true},
+		// or strict fails the ambiguous values
+		{values: []string{"02/03/2020", "2020-01-02"}, cfg: parseConfig{Strict: true}, strict: true},
+		{values: []string{"02/03/2020", "13/02/2020"}, cfg: parseConfig{Strict: true}, dayFirst: true},
+		{values: []string{"02/03/2020", "13/02/2020"}, cfg: parseConfig{RetrySwap: true}, dayFirst: true},
+	} {
+		c := tc.cfg.columnConfig(tc.values)
+		assert.Equal(t, tc.dayFirst, c.DayFirst, "%v", tc.values)
+		assert.Equal(t, tc.strict, c.Strict, "%v", tc.values)
+		assert.Equal(t, false, c.RetrySwap, "%v", tc.values)
+	}
+}
+
+func TestCsvColumn(t *testing.T) {
+	values := []string{"02/03/2020", "13/02/2020", "2020-01-02", "", "14/02/2020"}
+	col := newCsvColumn((&parseConfig{}).columnConfig(values), values)
+	assert.Equal(t, "02/01/2006", col.layout.Layout)
+	for _, tc := range []struct{ in, out string }{
+		{"05/04/2020", "2020-04-05"},
+		// other formats are read in the column's order
+		{"5/4/2020", "2020-04-05"},
+		{"2020-01-03", "2020-01-03"},
+	} {
+		ts, err := col.parse(tc.in)
+		assert.Equal(t, nil, err, tc.in)
+		assert.Equal(t, tc.out, ts.Format("2006-01-02"), tc.in)
+	}
+	_, err := col.parse("02/13/2020")
+	assert.NotEqual(t, nil, err)
+
+	// epochs have no layout, strict configs check every value
+	col = newCsvColumn(&parseConfig{}, []string{"1332151919", "1332151920"})
+	assert.Equal(t, (*dateparse.Layout)(nil), col.layout)
+	col = newCsvColumn(&parseConfig{Strictness: dateparse.StrictNoZone}, []string{"2020-01-02 10:00 +0100"})
+	assert.Equal(t, (*dateparse.Layout)(nil), col.layout)
+}
+
+func TestCsvNormalizer(t *testing.T) {
+	denver, err := time.LoadLocation("America/Denver")
+	assert.Equal(t, nil, err)
+	in := "id;created_at;note\n" +
+		"1;02/03/2020;ok\n" +
+		"2;\"13/02/2020\";\"two\nlines\"\n" +
+		"3;nope;bad\n" +
+		"4;;empty\n" +
+		"5;\"\n04/03/2020\";newline\n" +
+		"6;14/02/2020\n"
+
+	for _, tc := range []struct {
+		onError, out, errs string
+		err                bool
+	}{
+		{onError: "keep",
+			out: "id;created_at;note\n" +
+				"1;2020-03-02;ok\n" +
+				"2;2020-02-13;\"two\nlines\"\n" +
+				"3;nope;bad\n" +
+				"4;;empty\n" +
+				"5;2020-03-04;newline\n" +
+				"6;2020-02-14\n",
+			errs: "in.csv:5: column created_at: Could not find format for \"nope\"\n"},
+		{onError: "skip",
+			out: "id;created_at;note\n" +
+				"1;2020-03-02;ok\n" +
+				"2;2020-02-13;\"two\nlines\"\n" +
+				"4;;empty\n" +
+				"5;2020-03-04;newline\n" +
+				"6;2020-02-14\n",
+			errs: "in.csv:5: column created_at: Could not find format for \"nope\"\n"},
+		{onError: "fail", err: true},
+	} {
+		var out, errs bytes.Buffer
+		w := csv.NewWriter(&out)
+		w.Comma = ';'
+		cn := &csvNormalizer{cfg: &parseConfig{loc: denver}, columns: "created_at", header: true, comma: ';',
+			out: outputFormat("2006-01-02"), onError: tc.onError, name: "in.csv", errs: &errs}
+		err := cn.run(strings.NewReader(in), w)
+		if tc.err {
+			assert.NotEqual(t, nil, err, tc.onError)
+			assert.Equal(t, "in.csv:5: column created_at: Could not find format for \"nope\"", err.Error())
+			continue
+		}
+		assert.Equal(t, nil, err, tc.onError)
+		assert.Equal(t, tc.out, out.String(), tc.onError)
+		assert.Equal(t, tc.errs, errs.String(), tc.onError)
+	}
+
+	// by index without a header, malformed csv reports its line
+	var out bytes.Buffer
+	cn := &csvNormalizer{cfg: &parseConfig{}, columns: "2", comma: ',', out: outputFormat("epoch"), onError: "keep", name: "in.csv"}
+	err = cn.run(strings.NewReader("1,2020-01-02\n2,\"2020-01-03\n"), csv.NewWriter(&out))
+	assert.NotEqual(t, nil, err)
+	assert.Contains(t, err.Error(), "in.csv: parse error on line 2")
+
+	// lines are counted past blank lines, quoted newlines and lines
+	// longer than the read buffer
+	var errs bytes.Buffer
+	in = "a,b,c\n" +
+		"\n" +
+		"1,\"x\ny\",nope\n" +
+		"2,\"\n\",\"\nbad\"\n" +
+		"3," + strings.Repeat("z", 10000) + ",worse\n" +
+		"4,,2020-01-02"
+	cn = &csvNormalizer{cfg: &parseConfig{}, columns: "c", header: true, comma: ',', out: outputFormat("2006-01-02"), onError: "skip", name: "in.csv", errs: &errs}
+	out.Reset()
+	assert.Equal(t, nil, cn.run(strings.NewReader(in), csv.NewWriter(&out)))
+	assert.Equal(t, "a,b,c\n4,,2020-01-02\n", out.String())
+	assert.Equal(t, "in.csv:4: column c: Could not find format for \"nope\"\n"+
+		"in.csv:6: column c: Could not find format for \"bad\"\n"+
+		"in.csv:8: column c: Could not find format for \"worse\"\n", errs.String())
+
+	cn.columns = "name"
+	err = cn.run(strings.NewReader("1,2020-01-02\n"), csv.NewWriter(&out))
+	assert.Equal(t, `no column "name"`, err.Error())
+}
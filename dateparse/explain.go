@@ -0,0 +1,145 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/araddon/dateparse"
+	"github.com/scylladb/termtables"
+)
+
+type explainStep struct {
+	Kind      string `json:"kind"`
+	Offset    int    `json:"offset"`
+	Rune      string `json:"rune"`
+	DateState string `json:"date_state"`
+	TimeState string `json:"time_state"`
+	// Input is the rewritten input a restart continues on
+	Input string `json:"input,omitempty"`
+	// Layout written by a set step
+	Layout string `json:"layout,omitempty"`
+}
+
+// explainReport is what explain prints, or writes as json
+type explainReport struct {
+	Input     string            `json:"input"`
+	Steps     []explainStep     `json:"steps"`
+	Parsed    string            `json:"parsed,omitempty"`
+	Spans     []dateparse.Span  `json:"spans"`
+	Ignored   []dateparse.Span  `json:"ignored,omitempty"`
+	Layout    *dateparse.Layout `json:"layout,omitempty"`
+	Flags     string            `json:"flags,omitempty"`
+	Ambiguous bool              `json:"ambiguous"`
+	Time      string            `json:"time,omitempty"`
+	Error     string            `json:"error,omitempty"`
+	Options   []optionResult    `json:"options"`
+}
+
+// explain shows how the parser classified an input.
+//
+//	dateparse explain --json "03/04/2020 10:11 PM"
+func explain(args []string) error {
+	fs := flag.NewFlagSet("explain", flag.ExitOnError)
+	var cfg parseConfig
+	cfg.register(fs)
+	asJSON := fs.Bool("json", false, "write the explanation as json")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "usage: dateparse explain [flags] \"<date>\"\n\n")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		fs.Usage()
+		os.Exit(2)
+	}
+	if err := cfg.load(); err != nil {
+		return err
+	}
+	datestr := fs.Arg(0)
+
+	e, err := dateparse.ExplainIn(datestr, cfg.loc, cfg.options()...)
+	r := &explainReport{Input: datestr, Steps: []explainStep{}, Spans: []dateparse.Span{}}
+	for _, ev := range e.Steps {
+		step := explainStep{
+			Kind:      ev.Kind.String(),
+			Offset:    ev.Offset,
+			DateState: ev.DateState,
+			TimeState: ev.TimeState,
+			Layout:    ev.Layout,
+		}
+		if ev.Rune != 0 {
+			step.Rune = string(ev.Rune)
+		}
+		if ev.Kind == dateparse.TraceRestart {
+			step.Input = ev.Input
+		}
+		r.Steps = append(r.Steps, step)
+	}
+	if err != nil {
+		r.Error = err.Error()
+	} else {
+		if e.Parsed != datestr {
+			r.Parsed = e.Parsed
+		}
+		if e.Spans != nil {
+			r.Spans = e.Spans
+		}
+		r.Ignored = e.Ignored
+		r.Layout = &e.Layout
+		r.Flags = e.Layout.Flags.String()
+		r.Ambiguous = e.Ambiguous
+		r.Time = e.Time.Format(time.RFC3339Nano)
+	}
+	r.Options = cfg.compareOptions(datestr)
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(r)
+	}
+	r.print()
+	return nil
+}
+
+func (r *explainReport) print() {
+	fmt.Printf("input: %q\n\n", r.Input)
+	steps := termtables.CreateTable()
+	steps.AddHeaders("step", "offset", "rune", "date state", "time state", "layout")
+	for _, s := range r.Steps {
+		kind := s.Kind
+		if s.Input != "" {
+			kind = fmt.Sprintf("%s %q", s.Kind, s.Input)
+		}
+		r := ""
+		if s.Rune != "" {
+			r = fmt.Sprintf("%q", s.Rune)
+		}
+		layout := ""
+		if s.Layout != "" {
+			layout = fmt.Sprintf("%q", s.Layout)
+		}
+		steps.AddRow(kind, s.Offset, r, s.DateState, s.TimeState, layout)
+	}
+	fmt.Println(steps.Render())
+
+	if r.Error != "" {
+		fmt.Printf("error: %s\n\n", r.Error)
+	} else {
+		for _, s := range r.Ignored {
+			fmt.Printf("ignored: %-11s %2d-%-2d %q\n", s.Field, s.Start, s.End, s.Text)
+		}
+		if r.Parsed != "" {
+			fmt.Printf("parsed as: %q\n", r.Parsed)
+		}
+		for _, s := range r.Spans {
+			fmt.Printf("  %-8s %2d-%-2d %q\n", s.Field, s.Start, s.End, s.Text)
+		}
+		fmt.Printf("\nlayout: %q  flags: %s  ambiguous: %v\n", r.Layout.String(), r.Flags, r.Ambiguous)
+		fmt.Printf("time: %s\n\n", r.Time)
+	}
+
+	printOptions(r.Options)
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// outputFormats are the named output formats, anything else is used as a
+// go layout.
+var outputFormats = map[string]func(time.Time) string{
+	"rfc3339":     func(t time.Time) string { return t.Format(time.RFC3339) },
+	"rfc3339nano": func(t time.Time) string { return t.Format(time.RFC3339Nano) },
+	"epoch":       func(t time.Time) string { return strconv.FormatInt(t.Unix(), 10) },
+	"epochms":     func(t time.Time) string { return strconv.FormatInt(t.UnixNano()/int64(time.Millisecond), 10) },
+	"epochus":     func(t time.Time) string { return strconv.FormatInt(t.UnixNano()/int64(time.Microsecond), 10) },
+	"epochns":     func(t time.Time) string { return strconv.FormatInt(t.UnixNano(), 10) },
+}
+
+const outputFormatUsage = "output `format`: rfc3339, rfc3339nano, epoch, epochms, epochus, epochns or a go layout"
+
+// outputFormat returns the formatter for a format name or go layout
+func outputFormat(name string) func(time.Time) string {
+	if f, ok := outputFormats[strings.ToLower(name)]; ok {
+		return f
+	}
+	return func(t time.Time) string { return t.Format(name) }
+}
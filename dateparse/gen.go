@@ -0,0 +1,324 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"go/token"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/araddon/dateparse"
+)
+
+// maxGenTests is how many samples of each layout gen writes tests for
+const maxGenTests = 5
+
+// stdLayouts are the time package layout constants, gen names the
+// layouts matching one after it
+var stdLayouts = map[string]string{
+	time.ANSIC:       "ANSIC",
+	time.UnixDate:    "UnixDate",
+	time.RubyDate:    "RubyDate",
+	time.RFC822:      "RFC822",
+	time.RFC822Z:     "RFC822Z",
+	time.RFC850:      "RFC850",
+	time.RFC1123:     "RFC1123",
+	time.RFC1123Z:    "RFC1123Z",
+	time.RFC3339:     "RFC3339",
+	time.RFC3339Nano: "RFC3339Nano",
+	time.Kitchen:     "Kitchen",
+	time.Stamp:       "Stamp",
+	time.StampMilli:  "StampMilli",
+	time.StampMicro:  "StampMicro",
+	time.StampNano:   "StampNano",
+}
+
+// gen writes a go file with the layouts detected in sample dates, and a
+// Parse<Name> function trying just those with time.ParseInLocation, plus
+// table tests from the samples.
+//
+//	dateparse gen --package feeds samples.txt
+func gen(args []string) error {
+	fs := flag.NewFlagSet("gen", flag.ExitOnError)
+	var cfg parseConfig
+	cfg.register(fs)
+	pkg := fs.String("package", "", "go `package` of the generated files (required)")
+	name := fs.String("name", "", "generate Parse`Name`, defaults to the package name")
+	out := fs.String("out", "", "`file` to write, its tests go in the matching _test.go (default <name>_layouts.go)")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "usage: dateparse gen --package name [flags] [file ...]\n\nReads one sample date per line from the files or stdin.\n\n")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	if !isIdent(*pkg) {
+		return fmt.Errorf("--package must be a go package name, not %q", *pkg)
+	}
+	if *name == "" {
+		*name = strings.ToUpper((*pkg)[:1]) + (*pkg)[1:]
+	}
+	if !isIdent(*name) || !unicode.IsUpper(rune((*name)[0])) {
+		return fmt.Errorf("--name must be an exported go name, not %q", *name)
+	}
+	if *out == "" {
+		*out = strings.ToLower(*name) + "_layouts.go"
+	}
+	if !strings.HasSuffix(*out, ".go") || strings.HasSuffix(*out, "_test.go") {
+		return fmt.Errorf("--out must be a .go file that isn't a test, not %q", *out)
+	}
+	if err := cfg.load(); err != nil {
+		return err
+	}
+
+	g := newGenerator(&cfg, *pkg, *name, os.Stderr)
+	err := eachLine(fs.Args(), func(file string, lineno int, line string) error {
+		g.add(fmt.Sprintf("%s:%d", file, lineno), line)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	g.finish()
+	if len(g.layouts) == 0 {
+		return fmt.Errorf("no layouts found in the samples")
+	}
+
+	code, err := g.code()
+	if err != nil {
+		return err
+	}
+	tests, err := g.tests()
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(*out, code, 0644); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(strings.TrimSuffix(*out, ".go")+"_test.go", tests, 0644)
+}
+
+// isIdent is true if s can name a go package or func, not a keyword
+// (token.IsKeyword is go 1.13+)
+func isIdent(s string) bool {
+	for i, r := range s {
+		if !unicode.IsLetter(r) && r != '_' && (i == 0 || !unicode.IsDigit(r)) {
+			return false
+		}
+	}
+	return s != "" && !token.Lookup(s).IsKeyword()
+}
+
+type genLayout struct {
+	name    string
+	layout  string
+	order   string
+	count   int
+	example string
+	// utc the layout ends in a (Z)ulu "Z", it parses in UTC
+	utc bool
+}
+
+// parse is the generated code's reading of value
+func (gl *genLayout) parse(value string, loc *time.Location) (time.Time, error) {
+	if gl.utc {
+		loc = time.UTC
+	}
+	return time.ParseInLocation(gl.layout, value, loc)
+}
+
+// genTest is a sample and the time it must parse to, as RFC3339Nano
+type genTest struct {
+	in, out string
+	layout  *genLayout
+}
+
+// generator collects the layouts of sample dates, and writes the code
+// parsing them
+type generator struct {
+	cfg      *parseConfig
+	loc      *time.Location
+	pkg      string
+	name     string
+	layouts  []*genLayout
+	byLayout map[string]*genLayout
+	samples  []genTest
+	seen     map[string]bool
+	// warn gets samples that can't be parsed by the generated code
+	warn io.Writer
+}
+
+func newGenerator(cfg *parseConfig, pkg, name string, warn io.Writer) *generator {
+	loc := cfg.loc
+	if loc == nil {
+		loc = time.UTC
+	}
+	return &generator{
+		cfg:      cfg,
+		loc:      loc,
+		pkg:      pkg,
+		name:     name,
+		byLayout: make(map[string]*genLayout),
+		seen:     make(map[string]bool),
+		warn:     warn,
+	}
+}
+
+// add a sample, pos is where it came from for warnings
+func (g *generator) add(pos, line string) {
+	datestr := strings.TrimSpace(line)
+	if datestr == "" || g.seen[datestr] {
+		return
+	}
+	g.seen[datestr] = true
+	t, l, err := g.cfg.parseLayout(datestr)
+	if err != nil {
+		fmt.Fprintf(g.warn, "%s: skipped: %v\n", pos, err)
+		return
+	}
+	if l.Kind != dateparse.LayoutGo {
+		fmt.Fprintf(g.warn, "%s: skipped: %s is not a go layout\n", pos, l)
+		return
+	}
+	// rewritten inputs (ie ordinals, a leading weekday) don't match the
+	// layout as they are
+	direct, err := l.Parse(datestr, g.loc)
+	if l.Flags&dateparse.LayoutRewritten != 0 || err != nil || !direct.Equal(t) {
+		fmt.Fprintf(g.warn, "%s: skipped: %q needs rewriting before it matches %q\n", pos, datestr, l.Layout)
+		return
+	}
+	gl, ok := g.byLayout[l.Layout]
+	if !ok {
+		gl = &genLayout{layout: l.Layout, order: l.Order, example: datestr, utc: l.Flags&dateparse.LayoutUTC != 0}
+		g.byLayout[l.Layout] = gl
+		g.layouts = append(g.layouts, gl)
+	}
+	gl.count++
+	g.samples = append(g.samples, genTest{in: datestr, out: t.Format(time.RFC3339Nano), layout: gl})
+}
+
+// finish orders the layouts most common first and names them, after all
+// samples are added.  Samples an earlier layout reads differently are
+// dropped from the tests.
+func (g *generator) finish() {
+	sort.SliceStable(g.layouts, func(i, j int) bool {
+		return g.layouts[i].count > g.layouts[j].count
+	})
+	names := make(map[string]int)
+	for _, gl := range g.layouts {
+		base := g.name
+		if std, ok := stdLayouts[gl.layout]; ok {
+			base += std
+		} else if gl.order != "" {
+			base += gl.order
+		} else {
+			base += "Layout"
+		}
+		names[base]++
+		gl.name = base
+		if n := names[base]; n > 1 {
+			gl.name = fmt.Sprintf("%s%d", base, n)
+		}
+	}
+
+	samples := g.samples[:0]
+	perLayout := make(map[*genLayout]int)
+	for _, s := range g.samples {
+		for _, gl := range g.layouts {
+			t, err := gl.parse(s.in, g.loc)
+			if err != nil {
+				continue
+			}
+			if got := t.Format(time.RFC3339Nano); got != s.out {
+				fmt.Fprintf(g.warn, "%q: %s reads it as %s, not %s; not tested\n", s.in, gl.name, got, s.out)
+				s.layout = nil
+			}
+			break
+		}
+		if s.layout == nil || perLayout[s.layout] >= maxGenTests {
+			continue
+		}
+		perLayout[s.layout]++
+		samples = append(samples, s)
+	}
+	g.samples = samples
+}
+
+func (g *generator) unexported(name string) string {
+	return strings.ToLower(name[:1]) + name[1:]
+}
+
+// code is the go file with the layouts and Parse<Name>
+func (g *generator) code() ([]byte, error) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by dateparse gen; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "package %s\n\n", g.pkg)
+	fmt.Fprintf(&b, "import (\n\"fmt\"\n\"time\"\n)\n\n")
+	fmt.Fprintf(&b, "// Layouts detected in the samples, most common first.\nconst (\n")
+	for _, gl := range g.layouts {
+		fmt.Fprintf(&b, "// %s matched %d of the samples, ie %q\n", gl.name, gl.count, gl.example)
+		fmt.Fprintf(&b, "%s = %q\n", gl.name, gl.layout)
+	}
+	fmt.Fprintf(&b, ")\n\n")
+
+	list := g.unexported(g.name) + "Layouts"
+	fmt.Fprintf(&b, "// %s are tried in order by Parse%s\nvar %s = []string{\n", list, g.name, list)
+	for _, gl := range g.layouts {
+		fmt.Fprintf(&b, "%s,\n", gl.name)
+	}
+	fmt.Fprintf(&b, "}\n\n")
+
+	// go reads a (Z)ulu "Z" as text, the dates are in UTC
+	utc := ""
+	for _, gl := range g.layouts {
+		if gl.utc {
+			utc += gl.name + ": true,\n"
+		}
+	}
+	utcList := g.unexported(g.name) + "UTCLayouts"
+	if utc != "" {
+		fmt.Fprintf(&b, "// %s end in a Z for UTC, they parse in UTC\nvar %s = map[string]bool{\n%s}\n\n", utcList, utcList, utc)
+	}
+
+	fmt.Fprintf(&b, "// Parse%s parses value in the first of the detected layouts it\n", g.name)
+	fmt.Fprintf(&b, "// matches, dates without a zone or offset are in loc.\n")
+	fmt.Fprintf(&b, "func Parse%s(value string, loc *time.Location) (time.Time, error) {\n", g.name)
+	fmt.Fprintf(&b, "for _, layout := range %s {\n", list)
+	if utc != "" {
+		fmt.Fprintf(&b, "in := loc\nif %s[layout] {\nin = time.UTC\n}\n", utcList)
+		fmt.Fprintf(&b, "if t, err := time.ParseInLocation(layout, value, in); err == nil {\nreturn t, nil\n}\n}\n")
+	} else {
+		fmt.Fprintf(&b, "if t, err := time.ParseInLocation(layout, value, loc); err == nil {\nreturn t, nil\n}\n}\n")
+	}
+	fmt.Fprintf(&b, "return time.Time{}, fmt.Errorf(\"Parse%s: %%q matches none of the layouts\", value)\n}\n", g.name)
+	return format.Source(b.Bytes())
+}
+
+// tests is the _test.go file checking Parse<Name> against the samples
+func (g *generator) tests() ([]byte, error) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by dateparse gen; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "package %s\n\n", g.pkg)
+	fmt.Fprintf(&b, "import (\n\"testing\"\n\"time\"\n)\n\n")
+	fmt.Fprintf(&b, "func TestParse%s(t *testing.T) {\n", g.name)
+	fmt.Fprintf(&b, "loc, err := time.LoadLocation(%q)\n", g.loc.String())
+	fmt.Fprintf(&b, "if err != nil {\nt.Fatal(err)\n}\n")
+	fmt.Fprintf(&b, "for _, tc := range []struct{ in, out string }{\n")
+	for _, s := range g.samples {
+		fmt.Fprintf(&b, "{%q, %q},\n", s.in, s.out)
+	}
+	fmt.Fprintf(&b, "} {\n")
+	fmt.Fprintf(&b, "got, err := Parse%s(tc.in, loc)\n", g.name)
+	fmt.Fprintf(&b, "if err != nil {\nt.Errorf(\"%%q: %%v\", tc.in, err)\ncontinue\n}\n")
+	fmt.Fprintf(&b, "if s := got.Format(time.RFC3339Nano); s != tc.out {\n")
+	fmt.Fprintf(&b, "t.Errorf(\"%%q: got %%s want %%s\", tc.in, s, tc.out)\n}\n}\n")
+	fmt.Fprintf(&b, "if _, err := Parse%s(\"not a date\", loc); err == nil {\n", g.name)
+	fmt.Fprintf(&b, "t.Error(\"want an error for \\\"not a date\\\"\")\n}\n}\n")
+	return format.Source(b.Bytes())
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGen(t *testing.T) {
+	var warn bytes.Buffer
+	g := newGenerator(&parseConfig{}, "feeds", "Feeds", &warn)
+	for i, line := range []string{
+		"2020-01-02 10:11:12",
+		"2006-01-02T15:04:05Z",
+		"2020-01-03 10:11:12",
+		"",
+		"2020-01-03 10:11:12",
+		"03/04/2020",
+		"1332151919",
+		"nope",
+	} {
+		g.add(fmt.Sprintf("samples.txt:%d", i+1), line)
+	}
+	g.finish()
+
+	assert.Equal(t, 3, len(g.layouts))
+	assert.Equal(t, "FeedsYMD", g.layouts[0].name)
+	assert.Equal(t, 2, g.layouts[0].count)
+	assert.Equal(t, "FeedsMDY", g.layouts[2].name)
+	assert.Equal(t, 4, len(g.samples))
+	assert.True(t, strings.Contains(warn.String(), "samples.txt:7: skipped: epoch-s is not a go layout"), warn.String())
+	assert.True(t, strings.Contains(warn.String(), "samples.txt:8: skipped:"), warn.String())
+
+	code, err := g.code()
+	assert.Equal(t, nil, err)
+	assert.True(t, strings.HasPrefix(string(code), "// Code generated by dateparse gen; DO NOT EDIT.\n\npackage feeds\n"))
+	assert.True(t, strings.Contains(string(code), "\tFeedsYMD = \"2006-01-02 15:04:05\"\n"), string(code))
+	assert.True(t, strings.Contains(string(code), "func ParseFeeds(value string, loc *time.Location) (time.Time, error) {"))
+
+	tests, err := g.tests()
+	assert.Equal(t, nil, err)
+	assert.True(t, strings.Contains(string(tests), "func TestParseFeeds(t *testing.T) {"))
+	assert.True(t, strings.Contains(string(tests), `{"03/04/2020", "2020-03-04T00:00:00Z"},`), string(tests))
+
+	// an earlier layout reading a sample differently drops it from the tests
+	warn.Reset()
+	g = newGenerator(&parseConfig{}, "feeds", "Feeds", &warn)
+	g.add("a", "03/04/2020")
+	g.add("b", "04/03/2020")
+	g.cfg = &parseConfig{DayFirst: true}
+	g.add("c", "05/04/2020")
+	g.finish()
+	assert.Equal(t, 2, len(g.samples))
+	assert.Equal(t, "\"05/04/2020\": FeedsMDY reads it as 2020-05-04T00:00:00Z, not 2020-04-05T00:00:00Z; not tested\n", warn.String())
+
+	// a Z is UTC under --timezone, in the generated code too
+	warn.Reset()
+	cfg := &parseConfig{Timezone: "America/Denver"}
+	assert.Equal(t, nil, cfg.load())
+	g = newGenerator(cfg, "feeds", "Feeds", &warn)
+	g.add("a", "2017-07-19T03:22:00.123Z")
+	g.add("b", "2017-07-19 03:22:00")
+	g.finish()
+	assert.Equal(t, "", warn.String())
+	assert.Equal(t, 2, len(g.samples))
+	assert.Equal(t, "2017-07-19T03:22:00.123Z", g.samples[0].out)
+	assert.Equal(t, "2017-07-19T03:22:00-06:00", g.samples[1].out)
+	code, err = g.code()
+	assert.Equal(t, nil, err)
+	assert.True(t, strings.Contains(string(code), "var feedsUTCLayouts = map[string]bool{\n\tFeedsYMD: true,\n}"), string(code))
+	assert.True(t, strings.Contains(string(code), "\t\tif feedsUTCLayouts[layout] {\n\t\t\tin = time.UTC\n\t\t}\n"), string(code))
+}
+
+func TestIsIdent(t *testing.T) {
+	for _, tc := range []struct {
+		in string
+		ok bool
+	}{
+		{"feeds", true},
+		{"Feeds2", true},
+		{"_feeds", true},
+		{"", false},
+		{"2feeds", false},
+		{"my-feeds", false},
+		{"func", false},
+		{"type", false},
+		{"package", false},
+	} {
+		assert.Equal(t, tc.ok, isIdent(tc.in), tc.in)
+	}
+}
@@ -0,0 +1,252 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/araddon/dateparse"
+	"github.com/scylladb/termtables"
+)
+
+// maxFailureExamples is how many failed inputs infer reports
+const maxFailureExamples = 10
+
+type inferLayout struct {
+	Layout    string `json:"layout"`
+	Count     int    `json:"count"`
+	Ambiguous int    `json:"ambiguous"`
+	Example   string `json:"example"`
+
+	desc dateparse.Layout
+}
+
+type inferFailure struct {
+	File  string `json:"file,omitempty"`
+	Line  int    `json:"line"`
+	Value string `json:"value"`
+	Error string `json:"error"`
+}
+
+// inferReport is what infer prints, or writes as json
+type inferReport struct {
+	Total     int            `json:"total"`
+	Empty     int            `json:"empty"`
+	Ambiguous int            `json:"ambiguous"`
+	Failed    int            `json:"failed"`
+	Layouts   []*inferLayout `json:"layouts"`
+	Failures  []inferFailure `json:"failures"`
+	// Recommended is the layout that parses the most values, Matched of
+	// them.  Confidence is the fraction matched, halved when no value
+	// settles its month/day order.
+	Recommended    string  `json:"recommended"`
+	Order          string  `json:"order,omitempty"`
+	OrderConfirmed bool    `json:"order_confirmed"`
+	Matched        int     `json:"matched"`
+	Confidence     float64 `json:"confidence"`
+
+	cfg      *parseConfig
+	opts     []dateparse.ParserOption
+	values   []string
+	byLayout map[string]*inferLayout
+}
+
+func newInferReport(cfg *parseConfig) *inferReport {
+	return &inferReport{
+		Layouts:  []*inferLayout{},
+		Failures: []inferFailure{},
+		cfg:      cfg,
+		// values that don't parse in the preferred order may in the other
+		opts:     append(cfg.options(), dateparse.RetryAmbiguousDateWithSwap(true)),
+		byLayout: make(map[string]*inferLayout),
+	}
+}
+
+// add a value to the report, from line lineno of the file name
+func (r *inferReport) add(name string, lineno int, line string) {
+	r.Total++
+	datestr := strings.TrimSpace(line)
+	if datestr == "" {
+		r.Empty++
+		return
+	}
+	r.values = append(r.values, datestr)
+	l, err := dateparse.ParseLayout(datestr, r.opts...)
+	if err != nil {
+		r.Failed++
+		if len(r.Failures) < maxFailureExamples {
+			r.Failures = append(r.Failures, inferFailure{File: name, Line: lineno, Value: datestr, Error: err.Error()})
+		}
+		return
+	}
+	il, ok := r.byLayout[l.String()]
+	if !ok {
+		il = &inferLayout{Layout: l.String(), Example: datestr, desc: l}
+		r.byLayout[l.String()] = il
+		r.Layouts = append(r.Layouts, il)
+	}
+	il.Count++
+	il.desc.Flags |= l.Flags
+	if l.Flags&dateparse.LayoutAmbiguous != 0 {
+		il.Ambiguous++
+		r.Ambiguous++
+	}
+}
+
+// finish sorts the layouts by count and picks the recommended one, after
+// all values are added
+func (r *inferReport) finish() {
+	sort.SliceStable(r.Layouts, func(i, j int) bool {
+		return r.Layouts[i].Count > r.Layouts[j].Count
+	})
+	r.recommend()
+}
+
+// infer reports the layouts of a dataset, one value per line.
+//
+//	dateparse infer --json feed.txt
+func infer(args []string) error {
+	fs := flag.NewFlagSet("infer", flag.ExitOnError)
+	var cfg parseConfig
+	cfg.register(fs)
+	asJSON := fs.Bool("json", false, "write the report as json")
+	minConfidence := fs.Float64("min-confidence", 0, "exit with an error if the recommended layout's confidence is below `n` (0-1)")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "usage: dateparse infer [flags] [file ...]\n\nReads one date per line from the files or stdin.\n\n")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if err := cfg.load(); err != nil {
+		return err
+	}
+
+	report := newInferReport(&cfg)
+	err := eachLine(fs.Args(), func(name string, lineno int, line string) error {
+		report.add(name, lineno, line)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	report.finish()
+
+	if *asJSON {
+		if err := report.writeJSON(os.Stdout); err != nil {
+			return err
+		}
+	} else {
+		report.print(os.Stdout)
+	}
+	if report.Confidence < *minConfidence {
+		return fmt.Errorf("confidence %.2f is below %.2f", report.Confidence, *minConfidence)
+	}
+	return nil
+}
+
+// recommend picks the candidate layout (a detected one, or the other
+// month/day order of one) that parses the most values.
+func (r *inferReport) recommend() {
+	values := r.values
+	if len(r.Layouts) == 0 {
+		// nothing parsed
+		return
+	}
+	var candidates []dateparse.Layout
+	swaps := make(map[int]int)
+	for _, il := range r.Layouts {
+		candidates = append(candidates, il.desc)
+		if il.desc.Flags&dateparse.LayoutAmbiguous == 0 {
+			continue
+		}
+		dayFirst := il.desc.Flags&dateparse.LayoutDayFirst != 0
+		swap, err := dateparse.ParseLayout(il.Example, append(r.cfg.options(), dateparse.PreferMonthFirst(dayFirst))...)
+		if err != nil || swap.String() == il.Layout {
+			continue
+		}
+		swaps[len(candidates)-1] = len(candidates)
+		candidates = append(candidates, swap)
+	}
+
+	matches := make([][]bool, len(candidates))
+	counts := make([]int, len(candidates))
+	best := 0
+	for i, l := range candidates {
+		matches[i] = make([]bool, len(values))
+		for j, v := range values {
+			if r.parses(l, v) {
+				matches[i][j] = true
+				counts[i]++
+			}
+		}
+		if counts[i] > counts[best] {
+			best = i
+		}
+	}
+
+	l := candidates[best]
+	r.Recommended, r.Order, r.Matched = l.String(), l.Order, counts[best]
+	r.Confidence = float64(counts[best]) / float64(len(values))
+	r.OrderConfirmed = true
+	other, hasSwap := swaps[best]
+	for i, s := range swaps {
+		if s == best {
+			other, hasSwap = i, true
+		}
+	}
+	if hasSwap {
+		// confirmed if some value only parses in this order
+		r.OrderConfirmed = false
+		for j := range values {
+			if matches[best][j] && !matches[other][j] {
+				r.OrderConfirmed = true
+				break
+			}
+		}
+	}
+	if !r.OrderConfirmed {
+		r.Confidence /= 2
+	}
+}
+
+// parses is true if value parses in layout l
+func (r *inferReport) parses(l dateparse.Layout, value string) bool {
+	if l.Flags&dateparse.LayoutRewritten == 0 {
+		_, err := l.Parse(value, nil)
+		return err == nil
+	}
+	// the rewriting may need the configured options, ie --lenient
+	got, err := dateparse.ParseLayout(value, append(r.cfg.options(), dateparse.PreferMonthFirst(l.Flags&dateparse.LayoutDayFirst == 0))...)
+	return err == nil && got.Layout == l.Layout
+}
+
+func (r *inferReport) writeJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+func (r *inferReport) print(w io.Writer) {
+	table := termtables.CreateTable()
+	table.AddHeaders("layout", "count", "ambiguous", "example")
+	for _, il := range r.Layouts {
+		table.AddRow(il.Layout, il.Count, il.Ambiguous, il.Example)
+	}
+	fmt.Fprintln(w, table.Render())
+	fmt.Fprintf(w, "values: %d  empty: %d  ambiguous: %d  failed: %d\n", r.Total, r.Empty, r.Ambiguous, r.Failed)
+	for _, f := range r.Failures {
+		fmt.Fprintf(w, "  %s:%d: %q %s\n", f.File, f.Line, f.Value, f.Error)
+	}
+	if r.Recommended == "" {
+		return
+	}
+	confirmed := ""
+	if !r.OrderConfirmed {
+		confirmed = ", month/day order not settled by the data"
+	}
+	fmt.Fprintf(w, "\nrecommended layout: %q  order: %s  matches: %d  confidence: %.2f%s\n",
+		r.Recommended, r.Order, r.Matched, r.Confidence, confirmed)
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func inferLines(cfg *parseConfig, lines ...string) *inferReport {
+	r := newInferReport(cfg)
+	for i, line := range lines {
+		r.add("in.txt", i+1, line)
+	}
+	r.finish()
+	return r
+}
+
+func TestInferRecommend(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		cfg         parseConfig
+		lines       []string
+		recommended string
+		order       string
+		matched     int
+		confirmed   bool
+		confidence  float64
+	}{
+		{name: "consistent iso",
+			lines:       []string{"2020-01-02", "2020-01-03", "2020-12-31"},
+			recommended: "2006-01-02", order: "YMD", matched: 3, confirmed: true, confidence: 1},
+		// 13/03 only reads day first, which settles the order of the rest
+		{name: "day first settled",
+			lines:       []string{"02/03/2020", "04/03/2020", "13/03/2020", "nope"},
+			recommended: "02/01/2006", order: "DMY", matched: 3, confirmed: true, confidence: 0.75},
+		// nothing settles the order, so confidence is halved
+		{name: "ambiguous",
+			lines:       []string{"02/03/2020", "04/03/2020"},
+			recommended: "01/02/2006", order: "MDY", matched: 2, confirmed: false, confidence: 0.5},
+		{name: "ambiguous day first",
+			cfg:         parseConfig{DayFirst: true},
+			lines:       []string{"02/03/2020", "04/03/2020"},
+			recommended: "02/01/2006", order: "DMY", matched: 2, confirmed: false, confidence: 0.5},
+		// a tie goes to the most common detected layout
+		{name: "tie",
+			lines:       []string{"2020-01-02", "2020-01-03", "03.01.2020", "Jan 2 2020"},
+			recommended: "2006-01-02", order: "YMD", matched: 2, confirmed: true, confidence: 0.5},
+		{name: "tie first seen",
+			lines:       []string{"Jan 2 2020", "2020-01-02"},
+			recommended: "Jan 2 2006", order: "MDY", matched: 1, confirmed: true, confidence: 0.5},
+		// the swapped order is read with the configured options too
+		{name: "lenient",
+			cfg:         parseConfig{Lenient: true},
+			lines:       []string{"[02/03/2020]", "[04/03/2020]", "[13/03/2020]"},
+			recommended: "02/01/2006", order: "DMY", matched: 3, confirmed: true, confidence: 1},
+		{name: "nothing parses",
+			lines:       []string{"nope", ""},
+			recommended: "", matched: 0, confidence: 0},
+	} {
+		r := inferLines(&tc.cfg, tc.lines...)
+		assert.Equal(t, tc.recommended, r.Recommended, tc.name)
+		assert.Equal(t, tc.order, r.Order, tc.name)
+		assert.Equal(t, tc.matched, r.Matched, tc.name)
+		assert.Equal(t, tc.confirmed, r.OrderConfirmed, tc.name)
+		assert.InDelta(t, tc.confidence, r.Confidence, 0.001, tc.name)
+	}
+}
+
+func TestInferReport(t *testing.T) {
+	r := inferLines(&parseConfig{}, "02/03/2020", "", "13/03/2020", "2020-01-02", "nope", "02/03/2020")
+	assert.Equal(t, 6, r.Total)
+	assert.Equal(t, 1, r.Empty)
+	assert.Equal(t, 1, r.Failed)
+	assert.Equal(t, 3, r.Ambiguous)
+	assert.Equal(t, 3, len(r.Layouts))
+	// most common first
+	assert.Equal(t, &inferLayout{Layout: "01/02/2006", Count: 2, Ambiguous: 2, Example: "02/03/2020", desc: r.Layouts[0].desc}, r.Layouts[0])
+	assert.Equal(t, []inferFailure{{File: "in.txt", Line: 5, Value: "nope", Error: `Could not find format for "nope"`}}, r.Failures)
+
+	var out bytes.Buffer
+	assert.Equal(t, nil, r.writeJSON(&out))
+	var js map[string]interface{}
+	assert.Equal(t, nil, json.Unmarshal(out.Bytes(), &js))
+	assert.Equal(t, float64(6), js["total"])
+	assert.Equal(t, float64(1), js["failed"])
+	assert.Equal(t, "02/01/2006", js["recommended"])
+	assert.Equal(t, "DMY", js["order"])
+	assert.Equal(t, true, js["order_confirmed"])
+	assert.Equal(t, float64(3), js["matched"])
+	assert.InDelta(t, 0.6, js["confidence"], 0.001)
+	assert.Equal(t, map[string]interface{}{"layout": "01/02/2006", "count": float64(2), "ambiguous": float64(2), "example": "02/03/2020"}, js["layouts"].([]interface{})[0])
+	assert.Equal(t, map[string]interface{}{"file": "in.txt", "line": float64(5), "value": "nope", "error": `Could not find format for "nope"`}, js["failures"].([]interface{})[0])
+
+	// an empty dataset is still valid json, with empty lists
+	out.Reset()
+	assert.Equal(t, nil, inferLines(&parseConfig{}).writeJSON(&out))
+	assert.Contains(t, out.String(), `"layouts": []`)
+	assert.Contains(t, out.String(), `"failures": []`)
+	assert.NotContains(t, out.String(), `"order"`)
+
+	out.Reset()
+	r.print(&out)
+	assert.Contains(t, out.String(), "values: 6  empty: 1  ambiguous: 3  failed: 1\n")
+	assert.Contains(t, out.String(), "  in.txt:5: \"nope\" Could not find format for \"nope\"\n")
+	assert.Contains(t, out.String(), `recommended layout: "02/01/2006"  order: DMY  matches: 3  confidence: 0.60`)
+
+	// more failures than are reported
+	lines := make([]string, maxFailureExamples+5)
+	for i := range lines {
+		lines[i] = "nope"
+	}
+	r = inferLines(&parseConfig{}, lines...)
+	assert.Equal(t, maxFailureExamples+5, r.Failed)
+	assert.Equal(t, maxFailureExamples, len(r.Failures))
+
+	// failures say which file they are in
+	r = newInferReport(&parseConfig{})
+	r.add("a.txt", 1, "nope")
+	r.add("b.txt", 1, "nope")
+	r.finish()
+	assert.Equal(t, "a.txt", r.Failures[0].File)
+	assert.Equal(t, "b.txt", r.Failures[1].File)
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"bufio"
+	"io"
+	"os"
+)
+
+// eachLine calls fn for every line of the named files, or stdin when there
+// are none (or the name is "-").  Line numbers start at 1 per file, and the
+// line has its trailing \r\n removed.
+func eachLine(names []string, fn func(name string, lineno int, line string) error) error {
+	if len(names) == 0 {
+		names = []string{"-"}
+	}
+	for _, name := range names {
+		if err := eachFileLine(name, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func eachFileLine(name string, fn func(name string, lineno int, line string) error) error {
+	var r io.Reader = os.Stdin
+	if name != "-" {
+		f, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	} else {
+		name = "stdin"
+	}
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+	for lineno := 1; sc.Scan(); lineno++ {
+		line := sc.Text()
+		if n := len(line); n > 0 && line[n-1] == '\r' {
+			line = line[:n-1]
+		}
+		if err := fn(name, lineno, line); err != nil {
+			return err
+		}
+	}
+	return sc.Err()
+}
@@ -11,12 +11,32 @@ import (
 )
 
 var (
-	timezone = ""
-	datestr  = ""
+	cfg     parseConfig
+	datestr = ""
 )
 
+// commands are the subcommands, run with the args after their name
+var commands = map[string]func(args []string) error{
+	"normalize": normalize,
+	"csv":       csvColumns,
+	"infer":     infer,
+	"explain":   explain,
+	"serve":     serve,
+	"retime":    retime,
+	"gen":       gen,
+}
+
 func main() {
-	flag.StringVar(&timezone, "timezone", "", "Timezone aka `America/Los_Angeles` formatted time-zone")
+	if len(os.Args) > 1 {
+		if cmd, ok := commands[os.Args[1]]; ok {
+			if err := cmd(os.Args[2:]); err != nil {
+				fatal(err)
+			}
+			return
+		}
+	}
+
+	cfg.register(flag.CommandLine)
 	flag.Parse()
 
 	if len(flag.Args()) == 0 {
@@ -25,13 +45,30 @@ func main() {
 		./dateparse "2009-08-12T22:15:09.99Z" 
 
 		./dateparse --timezone="America/Denver" "2017-07-19 03:21:51+00:00"
+
+		./dateparse --day-first --retry-swap "13/02/2020"
+
+		./dateparse --config options.json "03/04/2020"
+
+		Or a command:
+
+		./dateparse normalize [flags] [file ...]
+		./dateparse csv --column name [flags] [file]
+		./dateparse infer [--json] [file ...]
+		./dateparse explain [--json] "<date>"
+		./dateparse serve [--addr :8080]
+		./dateparse retime [--to UTC] [file ...]
+		./dateparse gen --package name [file ...]
 		`)
 		return
 	}
 
 	datestr = flag.Args()[0]
 
-	layout, err := dateparse.ParseFormat(datestr)
+	if err := cfg.load(); err != nil {
+		fatal(err)
+	}
+	layout, err := dateparse.ParseFormat(datestr, cfg.options()...)
 	if err != nil {
 		fatal(err)
 	}
@@ -39,17 +76,12 @@ func main() {
 	zonename, _ := time.Now().In(time.Local).Zone()
 	fmt.Printf("\nYour Current time.Local zone is %v\n", zonename)
 	fmt.Printf("\nLayout String: dateparse.ParseFormat() => %v\n", layout)
-	var loc *time.Location
-	if timezone != "" {
-		// NOTE:  This is very, very important to understand
-		// time-parsing in go
-		l, err := time.LoadLocation(timezone)
-		if err != nil {
-			fatal(err)
-		}
-		loc = l
-		zonename, _ := time.Now().In(l).Zone()
-		fmt.Printf("\nYour Using time.Local set to location=%s %v \n", timezone, zonename)
+	// NOTE:  This is very, very important to understand
+	// time-parsing in go
+	loc := cfg.loc
+	if loc != nil {
+		zonename, _ := time.Now().In(loc).Zone()
+		fmt.Printf("\nYour Using time.Local set to location=%s %v \n", cfg.Timezone, zonename)
 	}
 	fmt.Printf("\n")
 
@@ -62,12 +94,13 @@ func main() {
 		"ParseIn":     parseIn,
 		"ParseLocal":  parseLocal,
 		"ParseStrict": parseStrict,
+		"ParseDate":   parseDate,
 	}
 
 	for name, parser := range parsers {
 		time.Local = nil
 		table.AddRow(name, "time.Local = nil", parser(datestr, nil, false), parser(datestr, nil, true))
-		if timezone != "" {
+		if loc != nil {
 			time.Local = loc
 			table.AddRow(name, "time.Local = timezone arg", parser(datestr, loc, false), parser(datestr, loc, true))
 		}
@@ -76,13 +109,16 @@ func main() {
 	}
 
 	fmt.Println(table.Render())
+
+	// how sensitive the input is to the month/day and retry options
+	printOptions(cfg.compareOptions(datestr))
 }
 
 type parser func(datestr string, loc *time.Location, utc bool) string
 
 func parseLocal(datestr string, loc *time.Location, utc bool) string {
 	time.Local = loc
-	t, err := dateparse.ParseLocal(datestr)
+	t, err := dateparse.ParseLocal(datestr, cfg.options()...)
 	if err != nil {
 		return err.Error()
 	}
@@ -93,7 +129,7 @@ func parseLocal(datestr string, loc *time.Location, utc bool) string {
 }
 
 func parseIn(datestr string, loc *time.Location, utc bool) string {
-	t, err := dateparse.ParseIn(datestr, loc)
+	t, err := dateparse.ParseIn(datestr, loc, cfg.options()...)
 	if err != nil {
 		return err.Error()
 	}
@@ -104,7 +140,7 @@ func parseIn(datestr string, loc *time.Location, utc bool) string {
 }
 
 func parseAny(datestr string, loc *time.Location, utc bool) string {
-	t, err := dateparse.ParseAny(datestr)
+	t, err := dateparse.ParseAny(datestr, cfg.options()...)
 	if err != nil {
 		return err.Error()
 	}
@@ -115,7 +151,7 @@ func parseAny(datestr string, loc *time.Location, utc bool) string {
 }
 
 func parseStrict(datestr string, loc *time.Location, utc bool) string {
-	t, err := dateparse.ParseStrict(datestr)
+	t, err := dateparse.ParseStrict(datestr, cfg.options()...)
 	if err != nil {
 		return err.Error()
 	}
@@ -125,7 +161,19 @@ func parseStrict(datestr string, loc *time.Location, utc bool) string {
 	return t.String()
 }
 
+// parseDate shows the civil date, and its start in loc as utc
+func parseDate(datestr string, loc *time.Location, utc bool) string {
+	d, err := dateparse.ParseDate(datestr, cfg.options()...)
+	if err != nil {
+		return err.Error()
+	}
+	if utc {
+		return d.In(loc).In(time.UTC).String()
+	}
+	return d.String()
+}
+
 func fatal(err error) {
-	fmt.Printf("fatal: %s\n", err)
+	fmt.Fprintf(os.Stderr, "fatal: %s\n", err)
 	os.Exit(1)
 }
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// normalize reads one date per line from files or stdin, and writes each
+// in the output format.
+//
+//	cat dates.txt | dateparse normalize --format epochms --on-error skip
+func normalize(args []string) error {
+	fs := flag.NewFlagSet("normalize", flag.ExitOnError)
+	var cfg parseConfig
+	cfg.register(fs)
+	format := fs.String("format", "rfc3339", outputFormatUsage)
+	onError := fs.String("on-error", "fail", "what to do with lines that don't parse: `skip|keep|fail`")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "usage: dateparse normalize [flags] [file ...]\n\nReads one date per line from the files or stdin.\n\n")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	switch *onError {
+	case "skip", "keep", "fail":
+	default:
+		return fmt.Errorf("--on-error must be skip, keep or fail, not %q", *onError)
+	}
+	if err := cfg.load(); err != nil {
+		return err
+	}
+	n := &normalizer{cfg: &cfg, out: outputFormat(*format), onError: *onError, errs: os.Stderr}
+
+	w := bufio.NewWriter(os.Stdout)
+	defer w.Flush()
+	return eachLine(fs.Args(), func(name string, lineno int, line string) error {
+		return n.line(w, name, lineno, line)
+	})
+}
+
+// normalizer writes lines in the output format, handling the ones that
+// don't parse as --on-error says
+type normalizer struct {
+	cfg     *parseConfig
+	out     func(time.Time) string
+	onError string
+	// errs gets the lines that don't parse, unless onError is fail
+	errs io.Writer
+}
+
+// line writes the normalized line to w, failing only if onError is fail
+// and it doesn't parse.
+func (n *normalizer) line(w io.Writer, name string, lineno int, line string) error {
+	datestr := strings.TrimSpace(line)
+	if datestr == "" {
+		_, err := fmt.Fprintln(w)
+		return err
+	}
+	t, err := n.cfg.parse(datestr)
+	if err != nil {
+		err = fmt.Errorf("%s:%d: %v", name, lineno, err)
+		if n.onError == "fail" {
+			return err
+		}
+		fmt.Fprintln(n.errs, err)
+		if n.onError == "skip" {
+			return nil
+		}
+		_, err = fmt.Fprintln(w, line)
+		return err
+	}
+	_, err = fmt.Fprintln(w, n.out(t))
+	return err
+}
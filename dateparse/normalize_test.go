@@ -0,0 +1,54 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	denver, err := time.LoadLocation("America/Denver")
+	assert.Equal(t, nil, err)
+	lines := []string{"2020-01-02", "03/04/2020 10:11", "", "nope", "1332151919"}
+
+	for _, tc := range []struct {
+		onError, out, errs string
+		err                bool
+	}{
+		{onError: "keep",
+			out:  "2020-01-02T00:00:00-07:00\n2020-03-04T10:11:00-07:00\n\nnope\n2012-03-19T04:11:59-06:00\n",
+			errs: "dates.txt:4: Could not find format for \"nope\"\n"},
+		{onError: "skip",
+			out:  "2020-01-02T00:00:00-07:00\n2020-03-04T10:11:00-07:00\n\n2012-03-19T04:11:59-06:00\n",
+			errs: "dates.txt:4: Could not find format for \"nope\"\n"},
+		// stops at the first line that doesn't parse, the error says where
+		{onError: "fail",
+			out: "2020-01-02T00:00:00-07:00\n2020-03-04T10:11:00-07:00\n\n",
+			err: true},
+	} {
+		var out, errs bytes.Buffer
+		n := &normalizer{cfg: &parseConfig{loc: denver}, out: outputFormat("rfc3339"), onError: tc.onError, errs: &errs}
+		var failed error
+		for i, line := range lines {
+			if failed = n.line(&out, "dates.txt", i+1, line); failed != nil {
+				break
+			}
+		}
+		assert.Equal(t, tc.out, out.String(), tc.onError)
+		assert.Equal(t, tc.errs, errs.String(), tc.onError)
+		if tc.err {
+			assert.NotEqual(t, nil, failed, tc.onError)
+			assert.Contains(t, failed.Error(), "dates.txt:4:", tc.onError)
+		} else {
+			assert.Equal(t, nil, failed, tc.onError)
+		}
+	}
+
+	// other formats, the date is read in the location
+	var out bytes.Buffer
+	n := &normalizer{cfg: &parseConfig{loc: denver}, out: outputFormat("epochms"), onError: "fail"}
+	assert.Equal(t, nil, n.line(&out, "dates.txt", 1, " 2020-01-02 "))
+	assert.Equal(t, "1577948400000\n", out.String())
+}
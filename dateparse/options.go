@@ -0,0 +1,213 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/araddon/dateparse"
+	"github.com/scylladb/termtables"
+)
+
+// parseConfig holds the parsing flags shared by the subcommands, which
+// are also read as json by serve and from a --config file.  Each
+// dateparse.ParserOption has a field here, set in options().
+type parseConfig struct {
+	Timezone  string `json:"timezone,omitempty"`
+	Strict    bool   `json:"strict,omitempty"`
+	DayFirst  bool   `json:"day_first,omitempty"`
+	Lenient   bool   `json:"lenient,omitempty"`
+	RetrySwap bool   `json:"retry_swap,omitempty"`
+	// Parallel goroutines for batches (csv), 0 parses serially and
+	// negative uses all cpus
+	Parallel int `json:"parallel,omitempty"`
+	// Strictness are the guesses to fail on, ie "two-digit-year|no-zone"
+	Strictness dateparse.Strictness `json:"strictness,omitempty"`
+	// Families of formats accepted, ie "iso8601|rfc1123", empty for all
+	Families dateparse.Family `json:"families,omitempty"`
+
+	loc  *time.Location
+	file string
+	fs   *flag.FlagSet
+}
+
+func (c *parseConfig) register(fs *flag.FlagSet) {
+	c.fs = fs
+	fs.StringVar(&c.file, "config", "", "json `file` of parse options, ie {\"timezone\": \"UTC\", \"day_first\": true}; flags override it")
+	fs.StringVar(&c.Timezone, "timezone", "", "Timezone aka `America/Los_Angeles` for dates without zone/offset")
+	fs.BoolVar(&c.Strict, "strict", false, "reject ambiguous mm/dd vs dd/mm dates")
+	fs.BoolVar(&c.DayFirst, "day-first", false, "read ambiguous dates as dd/mm")
+	fs.BoolVar(&c.Lenient, "lenient", false, "ignore wrapping quotes and brackets, filler words and stray punctuation")
+	fs.BoolVar(&c.RetrySwap, "retry-swap", false, "retry ambiguous dates in the other order when the month is out of range")
+	fs.IntVar(&c.Parallel, "parallel", 0, "parse batches on `n` goroutines, negative for all cpus")
+	fs.Var((*familyFlag)(&c.Families), "families", "only accept the format families `names` of: iso8601, rfc1123, ansic, slash, dot, colon, epoch, compact, year, cjk, alpha-month")
+	fs.Var((*strictnessFlag)(&c.Strictness), "strictness", "fail rather than guess, `names` of: ambiguous-mmdd, two-digit-year, zone-abbrev, epoch, no-zone, weekday, trailing-text, date, zone, all")
+}
+
+// strictnessFlag is a flag.Value for a dateparse.Strictness
+type strictnessFlag dateparse.Strictness
+
+func (s *strictnessFlag) String() string {
+	return dateparse.Strictness(*s).String()
+}
+
+func (s *strictnessFlag) Set(value string) error {
+	return (*dateparse.Strictness)(s).UnmarshalText([]byte(value))
+}
+
+// familyFlag is a flag.Value for a dateparse.Family
+type familyFlag dateparse.Family
+
+func (f *familyFlag) String() string {
+	if *f == 0 {
+		return ""
+	}
+	return dateparse.Family(*f).String()
+}
+
+func (f *familyFlag) Set(value string) error {
+	return (*dateparse.Family)(f).UnmarshalText([]byte(value))
+}
+
+// load the --config file and the timezone, call after the flags are parsed
+func (c *parseConfig) load() error {
+	if c.file != "" {
+		if err := c.loadFile(); err != nil {
+			return err
+		}
+	}
+	if c.Timezone == "" {
+		return nil
+	}
+	loc, err := time.LoadLocation(c.Timezone)
+	if err != nil {
+		return err
+	}
+	c.loc = loc
+	return nil
+}
+
+// loadFile reads the --config file over the config, keeping the flags
+// given on the command line.
+func (c *parseConfig) loadFile() error {
+	set := make(map[string]string)
+	if c.fs != nil {
+		c.fs.Visit(func(f *flag.Flag) {
+			set[f.Name] = f.Value.String()
+		})
+	}
+	b, err := ioutil.ReadFile(c.file)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(b, c); err != nil {
+		return fmt.Errorf("%s: %v", c.file, err)
+	}
+	for name, value := range set {
+		if err := c.fs.Set(name, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *parseConfig) options() []dateparse.ParserOption {
+	opts := []dateparse.ParserOption{
+		dateparse.PreferMonthFirst(!c.DayFirst),
+		dateparse.RetryAmbiguousDateWithSwap(c.RetrySwap),
+	}
+	if c.Lenient {
+		opts = append(opts, dateparse.Lenient(true))
+	}
+	if c.Parallel != 0 {
+		opts = append(opts, dateparse.Parallel(c.Parallel))
+	}
+	if c.Strictness != 0 {
+		opts = append(opts, dateparse.Strict(c.Strictness))
+	}
+	if c.Families != 0 {
+		opts = append(opts, dateparse.AllowFamilies(c.Families))
+	}
+	return opts
+}
+
+func (c *parseConfig) parse(datestr string) (time.Time, error) {
+	t, _, err := c.parseLayout(datestr)
+	return t, err
+}
+
+// parseLayout parses datestr per the config, with the layout it was in
+func (c *parseConfig) parseLayout(datestr string) (time.Time, dateparse.Layout, error) {
+	t, l, err := dateparse.ParseLayoutIn(datestr, c.loc, c.options()...)
+	if err != nil {
+		return time.Time{}, l, err
+	}
+	if c.Strict && l.Flags&dateparse.LayoutAmbiguous != 0 {
+		return time.Time{}, l, dateparse.ErrAmbiguousMMDD
+	}
+	return t, l, nil
+}
+
+// optionSet is a named combination of parser options, to compare how an
+// input reads under each.
+type optionSet struct {
+	name string
+	opts []dateparse.ParserOption
+}
+
+var optionSets = []optionSet{
+	{"month-first", []dateparse.ParserOption{dateparse.PreferMonthFirst(true)}},
+	{"day-first", []dateparse.ParserOption{dateparse.PreferMonthFirst(false)}},
+	{"month-first retry-swap", []dateparse.ParserOption{dateparse.PreferMonthFirst(true), dateparse.RetryAmbiguousDateWithSwap(true)}},
+	{"day-first retry-swap", []dateparse.ParserOption{dateparse.PreferMonthFirst(false), dateparse.RetryAmbiguousDateWithSwap(true)}},
+}
+
+// optionResult is how an input reads under one optionSet
+type optionResult struct {
+	Options string `json:"options"`
+	Time    string `json:"time,omitempty"`
+	Layout  string `json:"layout,omitempty"`
+	Error   string `json:"error,omitempty"`
+	// Changed the result differs from the configured options
+	Changed bool `json:"changed"`
+}
+
+// compareOptions parses datestr with the configured options, then with
+// each optionSet added to them, flagging those that read it differently.
+func (c *parseConfig) compareOptions(datestr string) []optionResult {
+	read := func(opts []dateparse.ParserOption) optionResult {
+		var r optionResult
+		e, err := dateparse.ExplainIn(datestr, c.loc, opts...)
+		if err != nil {
+			r.Error = err.Error()
+			return r
+		}
+		r.Time, r.Layout = e.Time.Format(time.RFC3339Nano), e.Layout.String()
+		return r
+	}
+	base := read(c.options())
+	results := make([]optionResult, len(optionSets))
+	for i, set := range optionSets {
+		results[i] = read(append(c.options(), set.opts...))
+		results[i].Options = set.name
+		results[i].Changed = results[i].Time != base.Time || results[i].Error != base.Error
+	}
+	return results
+}
+
+// printOptions renders compareOptions results side by side, marking the
+// option sets that change the result
+func printOptions(results []optionResult) {
+	table := termtables.CreateTable()
+	table.AddHeaders("options", "time", "layout", "error", "changed")
+	for _, o := range results {
+		changed := ""
+		if o.Changed {
+			changed = "*"
+		}
+		table.AddRow(o.Options, o.Time, o.Layout, o.Error, changed)
+	}
+	fmt.Println(table.Render())
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseConfigFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dateparse")
+	assert.Equal(t, nil, err)
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "options.json")
+	err = ioutil.WriteFile(file, []byte(`{"timezone": "America/Denver", "day_first": true, "retry_swap": true, "parallel": 2}`), 0644)
+	assert.Equal(t, nil, err)
+
+	// flags on the command line win over the file
+	var cfg parseConfig
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	cfg.register(fs)
+	assert.Equal(t, nil, fs.Parse([]string{"--config", file, "--day-first=false"}))
+	assert.Equal(t, nil, cfg.load())
+	assert.Equal(t, "America/Denver", cfg.loc.String())
+	assert.Equal(t, false, cfg.DayFirst)
+	assert.Equal(t, true, cfg.RetrySwap)
+	assert.Equal(t, 2, cfg.Parallel)
+	assert.Equal(t, 3, len(cfg.options()))
+
+	tm, err := cfg.parse("13/02/2020")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2020-02-13T00:00:00-07:00", tm.Format("2006-01-02T15:04:05Z07:00"))
+
+	err = ioutil.WriteFile(file, []byte(`{"day_first": "yes"}`), 0644)
+	assert.Equal(t, nil, err)
+	cfg = parseConfig{}
+	fs = flag.NewFlagSet("test", flag.ContinueOnError)
+	cfg.register(fs)
+	assert.Equal(t, nil, fs.Parse([]string{"--config", file}))
+	assert.NotEqual(t, nil, cfg.load())
+}
+
+func TestParseConfigStrictness(t *testing.T) {
+	var cfg parseConfig
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	cfg.register(fs)
+	assert.Equal(t, nil, fs.Parse([]string{"--strictness", "two-digit-year,no-zone"}))
+	assert.Equal(t, nil, cfg.load())
+	assert.Equal(t, "two-digit-year|no-zone", fs.Lookup("strictness").Value.String())
+	assert.Equal(t, 3, len(cfg.options()))
+
+	_, err := cfg.parse("2020-02-13 10:00")
+	assert.NotEqual(t, nil, err)
+	_, err = cfg.parse("2020-02-13 10:00 +0100")
+	assert.Equal(t, nil, err)
+
+	assert.NotEqual(t, nil, fs.Set("strictness", "lenient"))
+}
+
+func TestParseConfigFamilies(t *testing.T) {
+	var cfg parseConfig
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	cfg.register(fs)
+	assert.Equal(t, nil, fs.Parse([]string{"--families", "iso8601|rfc1123"}))
+	assert.Equal(t, nil, cfg.load())
+
+	_, err := cfg.parse("2020-02-13 10:00")
+	assert.Equal(t, nil, err)
+	_, err = cfg.parse("1332151919")
+	assert.NotEqual(t, nil, err)
+	assert.NotEqual(t, nil, fs.Set("families", "julian"))
+}
+
+func TestCompareOptions(t *testing.T) {
+	var cfg parseConfig
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	cfg.register(fs)
+	assert.Equal(t, nil, fs.Parse([]string{"--lenient"}))
+	assert.Equal(t, nil, cfg.load())
+
+	// each option set adds to --lenient, only the order changes the result
+	results := cfg.compareOptions("[03/04/2020]")
+	assert.Equal(t, 4, len(results))
+	for _, r := range results {
+		assert.Equal(t, "", r.Error, r.Options)
+	}
+	assert.Equal(t, "2020-03-04T00:00:00Z", results[0].Time)
+	assert.Equal(t, false, results[0].Changed)
+	assert.Equal(t, "2020-04-03T00:00:00Z", results[1].Time)
+	assert.Equal(t, true, results[1].Changed)
+}
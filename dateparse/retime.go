@@ -0,0 +1,201 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/araddon/dateparse"
+)
+
+// maxStampTokens is the most whitespace separated tokens a timestamp is
+// looked for in, ie "Fri Jul 03 2015 18:04:07 GMT+0100 (GMT Daylight Time)"
+const maxStampTokens = 8
+
+// stampTrim is the punctuation logs wrap timestamps in
+const stampTrim = `[]()<>{}"',;|`
+
+// stampWords are the words a timestamp may have besides zone
+// abbreviations, any other (ie a log level such as INFO) means the text
+// has run past the timestamp
+var stampWords = map[string]bool{
+	"t": true, "utc": true, "gmt": true, "z": true, "am": true, "pm": true,
+}
+
+// stampJunk are characters a timestamp never has inside it
+const stampJunk = `[]()<>{}"|;%=`
+
+func init() {
+	for _, m := range []string{"january", "february", "march", "april", "may", "june", "july",
+		"august", "september", "october", "november", "december"} {
+		stampWords[m] = true
+		stampWords[m[:3]] = true
+	}
+	for _, d := range []string{"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday"} {
+		stampWords[d] = true
+		stampWords[d[:3]] = true
+	}
+	stampWords["sept"] = true
+}
+
+// retime rewrites the timestamp in each log line into one zone and
+// format, passing the rest of the line through untouched.
+//
+//	tail -f app.log | dateparse retime --to UTC --format rfc3339nano
+func retime(args []string) error {
+	fs := flag.NewFlagSet("retime", flag.ExitOnError)
+	var cfg parseConfig
+	cfg.register(fs)
+	to := fs.String("to", "UTC", "`timezone` to write timestamps in, ie UTC, Local or America/Denver")
+	format := fs.String("format", "rfc3339", outputFormatUsage)
+	anywhere := fs.Bool("anywhere", false, "rewrite the first timestamp anywhere in the line, not just a leading one")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "usage: dateparse retime [flags] [file ...]\n\nReads log lines from the files or stdin.\n\n")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if err := cfg.load(); err != nil {
+		return err
+	}
+	toLoc, err := time.LoadLocation(*to)
+	if err != nil {
+		return err
+	}
+	rt := &retimer{cfg: &cfg, loc: toLoc, out: outputFormat(*format), anywhere: *anywhere, now: time.Now}
+
+	// flush every line, so it works on tail -f
+	w := bufio.NewWriter(os.Stdout)
+	return eachLine(fs.Args(), func(name string, lineno int, line string) error {
+		if _, err := io.WriteString(w, rt.line(line)+"\n"); err != nil {
+			return err
+		}
+		return w.Flush()
+	})
+}
+
+type retimer struct {
+	cfg      *parseConfig
+	loc      *time.Location
+	out      func(time.Time) string
+	anywhere bool
+	now      func() time.Time
+}
+
+type stampToken struct {
+	start, end int
+}
+
+// line returns the line with its timestamp rewritten, or unchanged if it
+// has none.
+func (rt *retimer) line(line string) string {
+	var toks []stampToken
+	start := -1
+	for i, r := range line {
+		switch {
+		case unicode.IsSpace(r) && start >= 0:
+			toks = append(toks, stampToken{start, i})
+			start = -1
+		case !unicode.IsSpace(r) && start < 0:
+			start = i
+		}
+	}
+	if start >= 0 {
+		toks = append(toks, stampToken{start, len(line)})
+	}
+
+	for s := range toks {
+		if s > 0 && !rt.anywhere {
+			break
+		}
+		n := maxStampTokens
+		if n > len(toks)-s {
+			n = len(toks) - s
+		}
+		// the longest run of tokens that reads as a timestamp
+		for ; n > 0; n-- {
+			begin, end := toks[s].start, toks[s+n-1].end
+			for begin < end && strings.IndexByte(stampTrim, line[begin]) >= 0 {
+				begin++
+			}
+			for end > begin && strings.IndexByte(stampTrim, line[end-1]) >= 0 {
+				end--
+			}
+			stamp := line[begin:end]
+			if !rt.plausible(stamp, s == 0) {
+				continue
+			}
+			t, l, err := rt.cfg.parseLayout(stamp)
+			if err != nil {
+				continue
+			}
+			if l.Flags&dateparse.LayoutNoYear != 0 && strings.Contains(l.Order, "M") {
+				t = rt.thisYear(t)
+			}
+			return line[:begin] + rt.out(t.In(rt.loc)) + line[end:]
+		}
+	}
+	return line
+}
+
+// thisYear puts a syslog style timestamp without a year in the current
+// year, or the last one if that would be more than a day in the future
+// (ie reading december logs in january).
+func (rt *retimer) thisYear(t time.Time) time.Time {
+	now := rt.now()
+	y := t.AddDate(now.Year()-t.Year(), 0, 0)
+	if y.Sub(now) > 24*time.Hour {
+		y = y.AddDate(-1, 0, 0)
+	}
+	return y
+}
+
+// isZoneAbbrev is true for words shaped like a zone abbreviation, ie EST,
+// MDT, CEST (but not log levels such as INFO or WARN)
+func isZoneAbbrev(word string) bool {
+	if len(word) < 3 || len(word) > 4 || word[len(word)-1] != 'T' {
+		return false
+	}
+	return strings.ToUpper(word) == word
+}
+
+// plausible filters out text the parser would accept that isn't a log
+// timestamp: bare numbers other than leading epochs, and runs with words
+// or punctuation that are not part of a timestamp.
+func (rt *retimer) plausible(stamp string, leading bool) bool {
+	if stamp == "" || strings.ContainsAny(stamp, stampJunk) {
+		return false
+	}
+	digits := 0
+	word := -1
+	for i, r := range stamp + " " {
+		switch {
+		case unicode.IsDigit(r):
+			digits++
+		case r < utf8.RuneSelf && unicode.IsLetter(r):
+			if word < 0 {
+				word = i
+			}
+			continue
+		}
+		if word >= 0 {
+			w := stamp[word:i]
+			if !stampWords[strings.ToLower(w)] && !isZoneAbbrev(w) {
+				return false
+			}
+			word = -1
+		}
+	}
+	if digits == 0 {
+		return false
+	}
+	if digits == len(stamp) {
+		return leading && digits >= len("1332151919")
+	}
+	return true
+}
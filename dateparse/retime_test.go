@@ -0,0 +1,38 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetime(t *testing.T) {
+	denver, err := time.LoadLocation("America/Denver")
+	assert.Equal(t, nil, err)
+	cfg := &parseConfig{loc: denver}
+	now := func() time.Time { return time.Date(2018, 1, 2, 0, 0, 0, 0, time.UTC) }
+	rt := &retimer{cfg: cfg, loc: time.UTC, out: outputFormat("rfc3339"), now: now}
+
+	for _, tc := range []struct{ in, out string }{
+		{"2017-07-19 03:21:00 INFO starting", "2017-07-19T09:21:00Z INFO starting"},
+		{"[2017-07-19 03:21:51+02:00] WARN disk 90% full", "[2017-07-19T01:21:51Z] WARN disk 90% full"},
+		{"2017-07-19T03:22:00Z ERROR 500", "2017-07-19T03:22:00Z ERROR 500"},
+		{"2017-07-19T03:22:00.123Z ERROR 500", "2017-07-19T03:22:00Z ERROR 500"},
+		{"1500434520 DEBUG epoch", "2017-07-19T03:22:00Z DEBUG epoch"},
+		{"Monday, 19-Jul-17 03:21:00 MDT x", "2017-07-19T09:21:00Z x"},
+		{"Fri Jul 03 2015 18:04:07 GMT+0100 (GMT Daylight Time)", "2015-07-03T17:04:07Z (GMT Daylight Time)"},
+		// syslog without a year, december is last year
+		{"Dec 31 23:00:00 host sshd[1]: ok", "2018-01-01T06:00:00Z host sshd[1]: ok"},
+		{"Jan  1 23:00:00 host sshd[1]: ok", "2018-01-02T06:00:00Z host sshd[1]: ok"},
+		{"no timestamp, 1234 requests", "no timestamp, 1234 requests"},
+		{"request at 2017/07/19 10:00:00 took 3ms", "request at 2017/07/19 10:00:00 took 3ms"},
+		{"", ""},
+	} {
+		assert.Equal(t, tc.out, rt.line(tc.in), tc.in)
+	}
+
+	rt.anywhere = true
+	assert.Equal(t, "request at 2017-07-19T16:00:00Z took 3ms", rt.line("request at 2017/07/19 10:00:00 took 3ms"))
+	assert.Equal(t, "no timestamp, 1234 requests", rt.line("no timestamp, 1234 requests"))
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/araddon/dateparse"
+)
+
+// serveRequest is the json body of every endpoint, Value for /parse and
+// /parse-format, Values for /batch and /infer.
+type serveRequest struct {
+	Value   string      `json:"value"`
+	Values  []string    `json:"values"`
+	Options parseConfig `json:"options"`
+}
+
+// serveError is the structured error in responses
+type serveError struct {
+	// Code is bad_request, too_large, bad_timezone, ambiguous or unparseable
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type serveResult struct {
+	Time   string            `json:"time,omitempty"`
+	Layout *dateparse.Layout `json:"layout,omitempty"`
+	Error  *serveError       `json:"error,omitempty"`
+}
+
+type batchResponse struct {
+	Results []serveResult  `json:"results"`
+	Layouts map[string]int `json:"layouts"`
+	Failed  int            `json:"failed"`
+}
+
+// serve runs a local http service with the parsing endpoints.
+//
+//	dateparse serve --addr :8080
+//	curl -d '{"value": "03/04/2020", "options": {"day_first": true}}' localhost:8080/parse
+func serve(args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	addr := fs.String("addr", ":8080", "`address` to listen on")
+	maxBody := fs.Int64("max-body", 1<<20, "largest request body in `bytes`")
+	fs.Parse(args)
+
+	log.Printf("dateparse listening on %s", *addr)
+	return http.ListenAndServe(*addr, newServer(*maxBody))
+}
+
+// newServer returns the handler for serve, bodies over maxBody bytes are
+// rejected.
+//
+//	POST /parse         {"value": "..."}   -> {"time", "layout"}
+//	POST /parse-format  {"value": "..."}   -> {"layout"}
+//	POST /batch         {"values": [...]}  -> {"results", "layouts", "failed"}
+//	POST /infer         {"values": [...]}  -> the infer report
+//
+// All take "options": {"timezone", "strict", "day_first", "retry_swap"}.
+func newServer(maxBody int64) http.Handler {
+	mux := http.NewServeMux()
+	handle := func(path string, fn func(req *serveRequest, cfg *parseConfig) (interface{}, *serveError)) {
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost {
+				w.Header().Set("Allow", http.MethodPost)
+				writeJSON(w, http.StatusMethodNotAllowed, serveResult{Error: &serveError{"bad_request", "use POST"}})
+				return
+			}
+			var req serveRequest
+			body := http.MaxBytesReader(w, r.Body, maxBody)
+			if err := json.NewDecoder(body).Decode(&req); err != nil {
+				status, code := http.StatusBadRequest, "bad_request"
+				if isTooLarge(err) {
+					status, code = http.StatusRequestEntityTooLarge, "too_large"
+				}
+				writeJSON(w, status, serveResult{Error: &serveError{code, err.Error()}})
+				return
+			}
+			cfg := req.Options
+			if err := cfg.load(); err != nil {
+				writeJSON(w, http.StatusBadRequest, serveResult{Error: &serveError{"bad_timezone", err.Error()}})
+				return
+			}
+			resp, serr := fn(&req, &cfg)
+			if serr != nil {
+				writeJSON(w, http.StatusUnprocessableEntity, serveResult{Error: serr})
+				return
+			}
+			writeJSON(w, http.StatusOK, resp)
+		})
+	}
+
+	handle("/parse", func(req *serveRequest, cfg *parseConfig) (interface{}, *serveError) {
+		r := cfg.serveParse(req.Value)
+		if r.Error != nil {
+			return nil, r.Error
+		}
+		return r, nil
+	})
+	handle("/parse-format", func(req *serveRequest, cfg *parseConfig) (interface{}, *serveError) {
+		r := cfg.serveParse(req.Value)
+		if r.Error != nil {
+			return nil, r.Error
+		}
+		return serveResult{Layout: r.Layout}, nil
+	})
+	handle("/batch", func(req *serveRequest, cfg *parseConfig) (interface{}, *serveError) {
+		resp := batchResponse{Results: make([]serveResult, len(req.Values)), Layouts: make(map[string]int)}
+		for i, v := range req.Values {
+			resp.Results[i] = cfg.serveParse(v)
+			if resp.Results[i].Error != nil {
+				resp.Failed++
+				continue
+			}
+			resp.Layouts[resp.Results[i].Layout.String()]++
+		}
+		return resp, nil
+	})
+	handle("/infer", func(req *serveRequest, cfg *parseConfig) (interface{}, *serveError) {
+		report := newInferReport(cfg)
+		for i, v := range req.Values {
+			report.add("", i+1, v)
+		}
+		report.finish()
+		return report, nil
+	})
+	return mux
+}
+
+// serveParse parses one value, with its layout
+func (c *parseConfig) serveParse(datestr string) serveResult {
+	t, l, err := c.parseLayout(datestr)
+	if err != nil {
+		code := "unparseable"
+		if err == dateparse.ErrAmbiguousMMDD {
+			code = "ambiguous"
+		}
+		return serveResult{Error: &serveError{code, err.Error()}}
+	}
+	return serveResult{Time: t.Format(time.RFC3339Nano), Layout: &l}
+}
+
+func isTooLarge(err error) bool {
+	// *http.MaxBytesError is go 1.19+, match the message it has always had
+	return err != nil && err.Error() == "http: request body too large"
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("writing response: %v", err)
+	}
+}
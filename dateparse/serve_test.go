@@ -0,0 +1,72 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func post(t *testing.T, srv *httptest.Server, path, body string) (int, map[string]interface{}) {
+	resp, err := http.Post(srv.URL+path, "application/json", strings.NewReader(body))
+	assert.Equal(t, nil, err)
+	defer resp.Body.Close()
+	var out map[string]interface{}
+	assert.Equal(t, nil, json.NewDecoder(resp.Body).Decode(&out))
+	return resp.StatusCode, out
+}
+
+func TestServe(t *testing.T) {
+	srv := httptest.NewServer(newServer(1024))
+	defer srv.Close()
+
+	status, out := post(t, srv, "/parse", `{"value": "03/04/2020 10:11", "options": {"day_first": true, "timezone": "America/Denver"}}`)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "2020-04-03T10:11:00-06:00", out["time"])
+	assert.Equal(t, "02/01/2006 15:04", out["layout"].(map[string]interface{})["layout"])
+
+	status, out = post(t, srv, "/parse", `{"value": "03/04/2020", "options": {"strict": true}}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, status)
+	assert.Equal(t, "ambiguous", out["error"].(map[string]interface{})["code"])
+
+	status, out = post(t, srv, "/parse", `{"value": "13/02/2020", "options": {"retry_swap": true}}`)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "2020-02-13T00:00:00Z", out["time"])
+
+	status, out = post(t, srv, "/parse", `{"value": "2020-01-02", "options": {"timezone": "Nowhere/Special"}}`)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "bad_timezone", out["error"].(map[string]interface{})["code"])
+
+	status, out = post(t, srv, "/parse-format", `{"value": "1332151919000"}`)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, map[string]interface{}{"kind": "epoch-ms"}, out["layout"])
+
+	status, out = post(t, srv, "/batch", `{"values": ["2020-01-02", "2020-01-03", "nope"]}`)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, float64(1), out["failed"])
+	assert.Equal(t, map[string]interface{}{"2006-01-02": float64(2)}, out["layouts"])
+	results := out["results"].([]interface{})
+	assert.Equal(t, 3, len(results))
+	assert.Equal(t, "unparseable", results[2].(map[string]interface{})["error"].(map[string]interface{})["code"])
+
+	status, out = post(t, srv, "/infer", `{"values": ["02/03/2020", "13/03/2020", ""]}`)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "02/01/2006", out["recommended"])
+	assert.Equal(t, float64(1), out["empty"])
+
+	status, out = post(t, srv, "/batch", `{"values": ["`+strings.Repeat("x", 2048)+`"]}`)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, status)
+	assert.Equal(t, "too_large", out["error"].(map[string]interface{})["code"])
+
+	status, out = post(t, srv, "/parse", `{"value": `)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "bad_request", out["error"].(map[string]interface{})["code"])
+
+	resp, err := http.Get(srv.URL + "/parse")
+	assert.Equal(t, nil, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+}
@@ -0,0 +1,375 @@
+package dateparse
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Dialect identifies a date-format pattern language that a detected
+// layout can be rendered in.
+type Dialect uint8
+
+const (
+	// DialectGo is the Go reference-time layout, ie "2006-01-02 15:04:05"
+	DialectGo Dialect = iota
+	// DialectStrftime is C/Python strftime, ie "%Y-%m-%d %H:%M:%S".  Unpadded
+	// fields use the common glibc "%-d" style flags.
+	DialectStrftime
+	// DialectJava is Java's DateTimeFormatter, ie "yyyy-MM-dd HH:mm:ss"
+	DialectJava
+	// DialectICU is ICU / Unicode LDML date patterns, ie "yyyy-MM-dd HH:mm:ss"
+	DialectICU
+	// DialectMoment is moment.js / dayjs tokens, ie "YYYY-MM-DD HH:mm:ss"
+	DialectMoment
+)
+
+var dialectNames = []string{
+	DialectGo:       "go",
+	DialectStrftime: "strftime",
+	DialectJava:     "java",
+	DialectICU:      "icu",
+	DialectMoment:   "moment",
+}
+
+func (d Dialect) String() string {
+	if int(d) < len(dialectNames) {
+		return dialectNames[d]
+	}
+	return fmt.Sprintf("Dialect(%d)", d)
+}
+
+type layoutElem uint8
+
+// Layout elements, these mirror the std chunks of the go time package.
+const (
+	elemLiteral layoutElem = iota
+	elemLongMonth
+	elemMonth
+	elemNumMonth
+	elemZeroMonth
+	elemLongWeekDay
+	elemWeekDay
+	elemDay
+	elemUnderDay
+	elemZeroDay
+	elemUnderYearDay
+	elemZeroYearDay
+	elemHour
+	elemHour12
+	elemZeroHour12
+	elemMinute
+	elemZeroMinute
+	elemSecond
+	elemZeroSecond
+	elemLongYear
+	elemYear
+	elemPM
+	elempm
+	elemTZ
+	elemISO8601TZ
+	elemISO8601SecondsTZ
+	elemISO8601ShortTZ
+	elemISO8601ColonTZ
+	elemISO8601ColonSecondsTZ
+	elemNumTZ
+	elemNumSecondsTz
+	elemNumShortTZ
+	elemNumColonTZ
+	elemNumColonSecondsTZ
+	elemFracSecond0
+	elemFracSecond9
+	// elements of foreign patterns that have no go layout equivalent
+	elemISOYear
+	elemISOWeek
+	elemISOWeekDay
+	elemNumWeekDay
+	elemSundayWeek
+	elemMondayWeek
+	elemUnixSeconds
+	elemUnixMillis
+	// strftime's %l, a 12 hour clock padded with a space
+	elemUnderHour12
+)
+
+// layoutChunk is one element of a go layout string.  For elemLiteral
+// text is the literal text, for the fractional seconds text holds the
+// separator and digits the number of digits.
+type layoutChunk struct {
+	elem   layoutElem
+	text   string
+	digits int
+}
+
+// layoutChunks splits a go layout into its elements, using the same
+// rules as the time package.
+func layoutChunks(layout string) []layoutChunk {
+	var chunks []layoutChunk
+	for len(layout) > 0 {
+		prefix, chunk, suffix := nextLayoutChunk(layout)
+		if len(prefix) > 0 {
+			if n := len(chunks); n > 0 && chunks[n-1].elem == elemLiteral {
+				chunks[n-1].text += prefix
+			} else {
+				chunks = append(chunks, layoutChunk{elem: elemLiteral, text: prefix})
+			}
+		}
+		if chunk.elem != elemLiteral {
+			chunks = append(chunks, chunk)
+		}
+		layout = suffix
+	}
+	return chunks
+}
+
+func nextLayoutChunk(layout string) (prefix string, chunk layoutChunk, suffix string) {
+	at := func(i, n int, elem layoutElem) (string, layoutChunk, string) {
+		return layout[0:i], layoutChunk{elem: elem, text: layout[i : i+n]}, layout[i+n:]
+	}
+	for i := 0; i < len(layout); i++ {
+		switch c := layout[i]; c {
+		case 'J': // January, Jan
+			if strings.HasPrefix(layout[i:], "Jan") {
+				if strings.HasPrefix(layout[i:], "January") {
+					return at(i, 7, elemLongMonth)
+				}
+				if !startsWithLower(layout[i+3:]) {
+					return at(i, 3, elemMonth)
+				}
+			}
+		case 'M': // Monday, Mon, MST
+			if strings.HasPrefix(layout[i:], "Mon") {
+				if strings.HasPrefix(layout[i:], "Monday") {
+					return at(i, 6, elemLongWeekDay)
+				}
+				if !startsWithLower(layout[i+3:]) {
+					return at(i, 3, elemWeekDay)
+				}
+			}
+			if strings.HasPrefix(layout[i:], "MST") {
+				return at(i, 3, elemTZ)
+			}
+		case '0': // 01, 02, 03, 04, 05, 06, 002
+			if len(layout) >= i+2 && '1' <= layout[i+1] && layout[i+1] <= '6' {
+				elems := []layoutElem{elemZeroMonth, elemZeroDay, elemZeroHour12, elemZeroMinute, elemZeroSecond, elemYear}
+				return at(i, 2, elems[layout[i+1]-'1'])
+			}
+			if strings.HasPrefix(layout[i:], "002") {
+				return at(i, 3, elemZeroYearDay)
+			}
+		case '1': // 15, 1
+			if strings.HasPrefix(layout[i:], "15") {
+				return at(i, 2, elemHour)
+			}
+			return at(i, 1, elemNumMonth)
+		case '2': // 2006, 2
+			if strings.HasPrefix(layout[i:], "2006") {
+				return at(i, 4, elemLongYear)
+			}
+			return at(i, 1, elemDay)
+		case '_': // _2, _2006, __2
+			if len(layout) >= i+2 && layout[i+1] == '2' {
+				// _2006 is really a literal _, followed by the long year
+				if strings.HasPrefix(layout[i+1:], "2006") {
+					return at(i+1, 4, elemLongYear)
+				}
+				return at(i, 2, elemUnderDay)
+			}
+			if strings.HasPrefix(layout[i:], "__2") {
+				return at(i, 3, elemUnderYearDay)
+			}
+		case '3':
+			return at(i, 1, elemHour12)
+		case '4':
+			return at(i, 1, elemMinute)
+		case '5':
+			return at(i, 1, elemSecond)
+		case 'P': // PM
+			if strings.HasPrefix(layout[i:], "PM") {
+				return at(i, 2, elemPM)
+			}
+		case 'p': // pm
+			if strings.HasPrefix(layout[i:], "pm") {
+				return at(i, 2, elempm)
+			}
+		case '-': // -070000, -07:00:00, -0700, -07:00, -07
+			switch {
+			case strings.HasPrefix(layout[i:], "-070000"):
+				return at(i, 7, elemNumSecondsTz)
+			case strings.HasPrefix(layout[i:], "-07:00:00"):
+				return at(i, 9, elemNumColonSecondsTZ)
+			case strings.HasPrefix(layout[i:], "-0700"):
+				return at(i, 5, elemNumTZ)
+			case strings.HasPrefix(layout[i:], "-07:00"):
+				return at(i, 6, elemNumColonTZ)
+			case strings.HasPrefix(layout[i:], "-07"):
+				return at(i, 3, elemNumShortTZ)
+			}
+		case 'Z': // Z070000, Z07:00:00, Z0700, Z07:00, Z07
+			switch {
+			case strings.HasPrefix(layout[i:], "Z070000"):
+				return at(i, 7, elemISO8601SecondsTZ)
+			case strings.HasPrefix(layout[i:], "Z07:00:00"):
+				return at(i, 9, elemISO8601ColonSecondsTZ)
+			case strings.HasPrefix(layout[i:], "Z0700"):
+				return at(i, 5, elemISO8601TZ)
+			case strings.HasPrefix(layout[i:], "Z07:00"):
+				return at(i, 6, elemISO8601ColonTZ)
+			case strings.HasPrefix(layout[i:], "Z07"):
+				return at(i, 3, elemISO8601ShortTZ)
+			}
+		case '.', ',': // .000 or .999, repeated digits for fractional seconds
+			if i+1 < len(layout) && (layout[i+1] == '0' || layout[i+1] == '9') {
+				ch := layout[i+1]
+				j := i + 1
+				for j < len(layout) && layout[j] == ch {
+					j++
+				}
+				// the string of digits must end here
+				if j >= len(layout) || layout[j] < '0' || layout[j] > '9' {
+					elem := elemFracSecond0
+					if ch == '9' {
+						elem = elemFracSecond9
+					}
+					return layout[0:i], layoutChunk{elem: elem, text: layout[i:j], digits: j - i - 1}, layout[j:]
+				}
+			}
+		}
+	}
+	return layout, layoutChunk{}, ""
+}
+
+func startsWithLower(s string) bool {
+	return len(s) > 0 && 'a' <= s[0] && s[0] <= 'z'
+}
+
+// dialectTokens maps each layout element to its equivalent token in every
+// Dialect, indexed by Dialect.  An empty token means the dialect has no
+// way of representing that element.
+var dialectTokens = map[layoutElem][5]string{
+	// Go, strftime, Java, ICU, moment
+	elemLongMonth:             {"January", "%B", "MMMM", "MMMM", "MMMM"},
+	elemMonth:                 {"Jan", "%b", "MMM", "MMM", "MMM"},
+	elemNumMonth:              {"1", "%-m", "M", "M", "M"},
+	elemZeroMonth:             {"01", "%m", "MM", "MM", "MM"},
+	elemLongWeekDay:           {"Monday", "%A", "EEEE", "EEEE", "dddd"},
+	elemWeekDay:               {"Mon", "%a", "EEE", "EEE", "ddd"},
+	elemDay:                   {"2", "%-d", "d", "d", "D"},
+	elemUnderDay:              {"_2", "%e", "ppd", "", ""},
+	elemZeroDay:               {"02", "%d", "dd", "dd", "DD"},
+	elemUnderYearDay:          {"__2", "", "pppD", "", ""},
+	elemZeroYearDay:           {"002", "%j", "DDD", "DDD", "DDDD"},
+	elemHour:                  {"15", "%H", "HH", "HH", "HH"},
+	elemHour12:                {"3", "%-I", "h", "h", "h"},
+	elemZeroHour12:            {"03", "%I", "hh", "hh", "hh"},
+	elemMinute:                {"4", "%-M", "m", "m", "m"},
+	elemZeroMinute:            {"04", "%M", "mm", "mm", "mm"},
+	elemSecond:                {"5", "%-S", "s", "s", "s"},
+	elemZeroSecond:            {"05", "%S", "ss", "ss", "ss"},
+	elemLongYear:              {"2006", "%Y", "yyyy", "yyyy", "YYYY"},
+	elemYear:                  {"06", "%y", "yy", "yy", "YY"},
+	elemPM:                    {"PM", "%p", "a", "a", "A"},
+	elempm:                    {"pm", "%P", "", "", "a"},
+	elemTZ:                    {"MST", "%Z", "z", "z", ""},
+	elemISO8601TZ:             {"Z0700", "", "XX", "XX", ""},
+	elemISO8601SecondsTZ:      {"Z070000", "", "XXXX", "XXXX", ""},
+	elemISO8601ShortTZ:        {"Z07", "", "X", "X", ""},
+	elemISO8601ColonTZ:        {"Z07:00", "", "XXX", "XXX", ""},
+	elemISO8601ColonSecondsTZ: {"Z07:00:00", "", "XXXXX", "XXXXX", ""},
+	elemNumTZ:                 {"-0700", "%z", "xx", "xx", "ZZ"},
+	elemNumSecondsTz:          {"-070000", "", "xxxx", "xxxx", ""},
+	elemNumShortTZ:            {"-07", "", "x", "x", ""},
+	elemNumColonTZ:            {"-07:00", "%:z", "xxx", "xxx", "Z"},
+	elemNumColonSecondsTZ:     {"-07:00:00", "", "xxxxx", "xxxxx", ""},
+}
+
+// ConvertLayout converts a go layout string (such as one returned by
+// ParseFormat) into the equivalent pattern of another Dialect.
+//
+//	pattern, err := dateparse.ConvertLayout("2006-01-02 15:04:05", dateparse.DialectStrftime)
+//	// pattern = "%Y-%m-%d %H:%M:%S"
+//
+// If some part of the layout has no equivalent in the dialect an error
+// naming that part is returned.
+func ConvertLayout(layout string, d Dialect) (string, error) {
+	if d == DialectGo {
+		return layout, nil
+	}
+	if int(d) >= len(dialectNames) {
+		return "", fmt.Errorf("unknown dialect %v", d)
+	}
+	var sb strings.Builder
+	for _, c := range layoutChunks(layout) {
+		switch c.elem {
+		case elemLiteral:
+			lit, err := quoteLiteral(c.text, d)
+			if err != nil {
+				return "", fmt.Errorf("layout %q: %v", layout, err)
+			}
+			sb.WriteString(lit)
+		case elemFracSecond0:
+			sep, _ := quoteLiteral(c.text[:1], d)
+			switch {
+			case d != DialectStrftime:
+				sb.WriteString(sep + strings.Repeat("S", c.digits))
+			case c.digits == 6:
+				// python's microseconds
+				sb.WriteString(sep + "%f")
+			default:
+				return "", unsupportedElem(layout, c, d)
+			}
+		default:
+			tok := dialectTokens[c.elem][d]
+			if tok == "" {
+				return "", unsupportedElem(layout, c, d)
+			}
+			sb.WriteString(tok)
+		}
+	}
+	return sb.String(), nil
+}
+
+func unsupportedElem(layout string, c layoutChunk, d Dialect) error {
+	return fmt.Errorf("layout %q: %q can not be represented in %v", layout, c.text, d)
+}
+
+// quoteLiteral escapes literal layout text so the dialect will not read
+// it as pattern tokens.
+func quoteLiteral(lit string, d Dialect) (string, error) {
+	switch d {
+	case DialectStrftime:
+		return strings.Replace(lit, "%", "%%", -1), nil
+	case DialectJava, DialectICU:
+		if strings.IndexFunc(lit, isPatternRune) < 0 && !strings.ContainsAny(lit, "'[]{}#") {
+			return lit, nil
+		}
+		return "'" + strings.Replace(lit, "'", "''", -1) + "'", nil
+	case DialectMoment:
+		if strings.IndexFunc(lit, isPatternRune) < 0 && !strings.Contains(lit, "[") {
+			return lit, nil
+		}
+		if strings.Contains(lit, "]") {
+			return "", fmt.Errorf("literal %q can not be escaped in %v", lit, d)
+		}
+		return "[" + lit + "]", nil
+	}
+	return lit, nil
+}
+
+// isPatternRune is true for the ascii letters that pattern languages
+// reserve for tokens.
+func isPatternRune(r rune) bool {
+	return ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z')
+}
+
+// ParseFormatDialect is ParseFormat, returning the layout rendered in the
+// given Dialect instead of as a go layout.
+//
+//	pattern, err := dateparse.ParseFormatDialect("2013-02-01 00:00:00", dateparse.DialectJava)
+//	// pattern = "yyyy-MM-dd HH:mm:ss"
+func ParseFormatDialect(datestr string, d Dialect, opts ...ParserOption) (string, error) {
+	l, err := ParseLayout(datestr, opts...)
+	if err != nil {
+		return "", err
+	}
+	return l.Convert(d)
+}
@@ -0,0 +1,96 @@
+package dateparse
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type dialectTest struct {
+	in                              string
+	strftime, java, icu, moment     string
+	strftimeErr, javaErr, momentErr bool
+}
+
+var testDialects = []dialectTest{
+	{in: "2006-01-02 15:04:05", strftime: "%Y-%m-%d %H:%M:%S", java: "yyyy-MM-dd HH:mm:ss", icu: "yyyy-MM-dd HH:mm:ss", moment: "YYYY-MM-DD HH:mm:ss"},
+	{in: "2006-01-02T15:04:05-07:00", strftime: "%Y-%m-%dT%H:%M:%S%:z", java: "yyyy-MM-dd'T'HH:mm:ssxxx", icu: "yyyy-MM-dd'T'HH:mm:ssxxx", moment: "YYYY-MM-DD[T]HH:mm:ssZ"},
+	{in: "2006-01-02T15:04:05Z07:00", java: "yyyy-MM-dd'T'HH:mm:ssXXX", icu: "yyyy-MM-dd'T'HH:mm:ssXXX", strftimeErr: true, momentErr: true},
+	{in: "Jan 2, 2006 3:04:05 PM", strftime: "%b %-d, %Y %-I:%M:%S %p", java: "MMM d, yyyy h:mm:ss a", icu: "MMM d, yyyy h:mm:ss a", moment: "MMM D, YYYY h:mm:ss A"},
+	// java and icu read their am/pm marker "a" in upper case only
+	{in: "Jan 2, 2006 3:04:05 pm", strftime: "%b %-d, %Y %-I:%M:%S %P", moment: "MMM D, YYYY h:mm:ss a", javaErr: true},
+	{in: "Mon Jan _2 15:04:05 MST 2006", strftime: "%a %b %e %H:%M:%S %Z %Y", java: "EEE MMM ppd HH:mm:ss z yyyy", momentErr: true},
+	{in: "02 January 2006", strftime: "%d %B %Y", java: "dd MMMM yyyy", icu: "dd MMMM yyyy", moment: "DD MMMM YYYY"},
+	{in: "02/Jan/2006:15:04:05 -0700", strftime: "%d/%b/%Y:%H:%M:%S %z", java: "dd/MMM/yyyy:HH:mm:ss xx", icu: "dd/MMM/yyyy:HH:mm:ss xx", moment: "DD/MMM/YYYY:HH:mm:ss ZZ"},
+	{in: "2006-01-02 15:04:05.000000", strftime: "%Y-%m-%d %H:%M:%S.%f", java: "yyyy-MM-dd HH:mm:ss.SSSSSS", icu: "yyyy-MM-dd HH:mm:ss.SSSSSS", moment: "YYYY-MM-DD HH:mm:ss.SSSSSS"},
+	{in: "2006-01-02 15:04:05.000", java: "yyyy-MM-dd HH:mm:ss.SSS", icu: "yyyy-MM-dd HH:mm:ss.SSS", moment: "YYYY-MM-DD HH:mm:ss.SSS", strftimeErr: true},
+	{in: "2006-01-02 15:04:05.999", strftimeErr: true, javaErr: true, momentErr: true},
+	{in: "2006年01月02日", strftime: "%Y年%m月%d日", java: "yyyy年MM月dd日", icu: "yyyy年MM月dd日", moment: "YYYY年MM月DD日"},
+	{in: "~% 2006", strftime: "~%% %Y", java: "~% yyyy", icu: "~% yyyy", moment: "~% YYYY"},
+	{in: "it's 2006", strftime: "it's %Y", java: "'it''s 'yyyy", icu: "'it''s 'yyyy", moment: "[it's ]YYYY"},
+}
+
+func TestConvertLayout(t *testing.T) {
+	for _, th := range testDialects {
+		got, err := ConvertLayout(th.in, DialectStrftime)
+		if th.strftimeErr {
+			assert.NotEqual(t, nil, err, "strftime %q got %q", th.in, got)
+		} else {
+			assert.Equal(t, nil, err, "strftime %q", th.in)
+			assert.Equal(t, th.strftime, got, "strftime %q", th.in)
+		}
+
+		got, err = ConvertLayout(th.in, DialectJava)
+		if th.javaErr {
+			assert.NotEqual(t, nil, err, "java %q got %q", th.in, got)
+		} else {
+			assert.Equal(t, nil, err, "java %q", th.in)
+			assert.Equal(t, th.java, got, "java %q", th.in)
+		}
+
+		got, err = ConvertLayout(th.in, DialectICU)
+		if th.icu == "" {
+			assert.NotEqual(t, nil, err, "icu %q got %q", th.in, got)
+		} else {
+			assert.Equal(t, nil, err, "icu %q", th.in)
+			assert.Equal(t, th.icu, got, "icu %q", th.in)
+		}
+
+		got, err = ConvertLayout(th.in, DialectMoment)
+		if th.momentErr {
+			assert.NotEqual(t, nil, err, "moment %q got %q", th.in, got)
+		} else {
+			assert.Equal(t, nil, err, "moment %q", th.in)
+			assert.Equal(t, th.moment, got, "moment %q", th.in)
+		}
+
+		got, err = ConvertLayout(th.in, DialectGo)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, th.in, got)
+	}
+
+	_, err := ConvertLayout("2006", Dialect(99))
+	assert.NotEqual(t, nil, err)
+	assert.Equal(t, "strftime", DialectStrftime.String())
+}
+
+func TestParseFormatDialect(t *testing.T) {
+	pattern, err := ParseFormatDialect("2013-02-01 00:00:00", DialectJava)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "yyyy-MM-dd HH:mm:ss", pattern)
+
+	pattern, err = ParseFormatDialect("May 8, 2009 5:57:51 PM", DialectStrftime)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "%b %-d, %Y %-I:%M:%S %p", pattern)
+
+	pattern, err = ParseFormatDialect("1332151919", DialectStrftime)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "%s", pattern)
+
+	// java has no epoch pattern
+	_, err = ParseFormatDialect("1332151919", DialectJava)
+	assert.NotEqual(t, nil, err)
+
+	_, err = ParseFormatDialect("INVALID", DialectStrftime)
+	assert.NotEqual(t, nil, err)
+}
@@ -0,0 +1,124 @@
+package dateparse
+
+import "time"
+
+// Span is where a date/time field was found in a string, [Start, End)
+// byte offsets.
+type Span struct {
+	// Field is year, month, day, weekday, hour, minute, second, fraction,
+	// ampm, offset, zone or epoch, or what Clean ignored
+	Field string `json:"field"`
+	Start int    `json:"start"`
+	End   int    `json:"end"`
+	Text  string `json:"text"`
+}
+
+// Explanation describes how the parser read a date string, see Explain.
+type Explanation struct {
+	Input string
+	// Steps are the state machine transitions and restarts, in order
+	Steps []TraceEvent
+	// Parsed is the string the layout applied to, which differs from Input
+	// when it had to be rewritten (see LayoutRewritten).  Spans are
+	// offsets into Parsed, or into Input when it was only normalized (see
+	// Normalize).
+	Parsed string
+	Layout Layout
+	Spans  []Span
+	// Ignored is what the Lenient option cleaned from Input, offsets into
+	// Input
+	Ignored []Span
+	// Ambiguous the date could be read mm/dd or dd/mm
+	Ambiguous bool
+	Time      time.Time
+}
+
+// Explain parses datestr like ParseAny, recording each step the state
+// machine took, the fields it found and the layout it settled on.  It is
+// a debugging aid: when a date parses wrong it shows why.  On failure the
+// Explanation holds the steps taken up to the error.
+func Explain(datestr string, opts ...ParserOption) (*Explanation, error) {
+	return ExplainIn(datestr, nil, opts...)
+}
+
+// ExplainIn is Explain with a location, same rules as ParseIn.
+func ExplainIn(datestr string, loc *time.Location, opts ...ParserOption) (*Explanation, error) {
+	e := &Explanation{Input: datestr}
+	opts = append(opts[:len(opts):len(opts)], WithTrace(func(ev TraceEvent) {
+		e.Steps = append(e.Steps, ev)
+	}))
+	p, err := parseTime(datestr, loc, opts...)
+	if err != nil {
+		return e, err
+	}
+	if e.Time, err = p.parse(); err != nil {
+		return e, err
+	}
+	e.Parsed = p.datestr
+	e.Ignored = p.ignored
+	e.Layout = p.layout(datestr)
+	e.Ambiguous = e.Layout.Flags&LayoutAmbiguous != 0
+	if e.Layout.Kind != LayoutGo {
+		e.Spans = []Span{{Field: "epoch", End: len(p.datestr), Text: p.datestr}}
+		return e, nil
+	}
+	e.Spans = p.spans(datestr, e.Layout)
+	return e, nil
+}
+
+// spans are where the fields of layout l are in the input datestr, after
+// a parse, or nil if the layout doesn't match (time.Parse is more
+// forgiving).  They are offsets into p.datestr when it was rewritten.
+func (p *parser) spans(datestr string, l Layout) []Span {
+	offsets := p.offsets
+	if normalized, _ := Normalize(datestr); p.datestr != normalized {
+		offsets = nil
+	}
+	chunks := layoutChunks(l.Layout)
+	m, err := matchChunks(chunks, p.datestr)
+	if err != nil {
+		return nil
+	}
+	var spans []Span
+	for i, c := range chunks {
+		field := elemField(c.elem)
+		if field == "" {
+			continue
+		}
+		start, end := m.spans[i][0], m.spans[i][1]
+		sp := Span{Field: field, Start: start, End: end, Text: p.datestr[start:end]}
+		spans = append(spans, inputSpan(datestr, offsets, sp))
+	}
+	return spans
+}
+
+// elemField names the field of a layout element, "" for literals.
+func elemField(elem layoutElem) string {
+	switch elem {
+	case elemLongYear, elemYear:
+		return "year"
+	case elemLongMonth, elemMonth, elemNumMonth, elemZeroMonth:
+		return "month"
+	case elemDay, elemUnderDay, elemZeroDay:
+		return "day"
+	case elemLongWeekDay, elemWeekDay:
+		return "weekday"
+	case elemHour, elemHour12, elemZeroHour12, elemUnderHour12:
+		return "hour"
+	case elemMinute, elemZeroMinute:
+		return "minute"
+	case elemSecond, elemZeroSecond:
+		return "second"
+	case elemFracSecond0, elemFracSecond9:
+		return "fraction"
+	case elemPM, elempm:
+		return "ampm"
+	case elemTZ:
+		return "zone"
+	case elemISO8601TZ, elemISO8601SecondsTZ, elemISO8601ShortTZ, elemISO8601ColonTZ,
+		elemISO8601ColonSecondsTZ, elemNumTZ, elemNumSecondsTz, elemNumShortTZ,
+		elemNumColonTZ, elemNumColonSecondsTZ:
+		return "offset"
+	}
+	return ""
+}
@@ -0,0 +1,91 @@
+package dateparse
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExplain(t *testing.T) {
+	time.Local = time.UTC
+
+	e, err := Explain("03/04/2020 10:11 PM")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, e.Ambiguous)
+	assert.Equal(t, "01/02/2006 15:04 PM", e.Layout.Layout)
+	assert.Equal(t, "2020-03-04 22:11:00 +0000 UTC", e.Time.String())
+	assert.Equal(t, []TraceEvent{
+		{TraceTransition, "03/04/2020 10:11 PM", 0, '0', "dateDigit", "timeIgnore", ""},
+		{TraceTransition, "03/04/2020 10:11 PM", 2, '/', "dateDigitSlash", "timeIgnore", ""},
+		{TraceSet, "03/04/2020 10:11 PM", 0, '0', "dateDigitSlash", "timeIgnore", "01"},
+		{TraceSet, "03/04/2020 10:11 PM", 3, '0', "dateDigitSlash", "timeIgnore", "02"},
+		{TraceTransition, "03/04/2020 10:11 PM", 10, ' ', "dateDigitSlash", "timeStart", ""},
+		{TraceSet, "03/04/2020 10:11 PM", 6, '2', "dateDigitSlash", "timeStart", "2006"},
+		{TraceSet, "03/04/2020 10:11 PM", 6, '2', "dateDigitSlash", "timeStart", "2006"},
+		{TraceSet, "03/04/2020 10:11 PM", 11, '1', "dateDigitSlash", "timeStart", "15"},
+		{TraceSet, "03/04/2020 10:11 PM", 14, '1', "dateDigitSlash", "timeStart", "04"},
+		{TraceTransition, "03/04/2020 10:11 PM", 16, ' ', "dateDigitSlash", "timeWs", ""},
+		{TraceTransition, "03/04/2020 10:11 PM", 17, 'P', "dateDigitSlash", "timeWsAMPMMaybe", ""},
+		{TraceTransition, "03/04/2020 10:11 PM", 18, 'M', "dateDigitSlash", "timeWsAMPM", ""},
+		{TraceSet, "03/04/2020 10:11 PM", 17, 'P', "dateDigitSlash", "timeWsAMPM", "PM"},
+		{TraceSet, "03/04/2020 10:11 PM", 11, '1', "dateDigitSlash", "timeWsAMPM", "03"},
+		{TraceSet, "03/04/2020 10:11 PM", 11, '1', "dateDigitSlash", "timeWsAMPM", "15"},
+		{TraceSet, "03/04/2020 10:11 PM", 14, '1', "dateDigitSlash", "timeWsAMPM", "04"},
+	}, e.Steps)
+	assert.Equal(t, []Span{
+		{"month", 0, 2, "03"},
+		{"day", 3, 5, "04"},
+		{"year", 6, 10, "2020"},
+		{"hour", 11, 13, "10"},
+		{"minute", 14, 16, "11"},
+		{"ampm", 17, 19, "PM"},
+	}, e.Spans)
+
+	// restarts are recorded, and spans are into the rewritten string
+	e, err = Explain("Tue 05 May 2020, 05:05:05", PreferMonthFirst(false))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, TraceRestart, e.Steps[1].Kind)
+	assert.Equal(t, "05 May 2020, 05:05:05", e.Steps[1].Input)
+	assert.Equal(t, "05 May 2020, 05:05:05", e.Parsed)
+	assert.Equal(t, Span{"month", 3, 6, "May"}, e.Spans[1])
+
+	e, err = ExplainIn("1332151919", time.UTC)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, LayoutEpochSeconds, e.Layout.Kind)
+	assert.Equal(t, []Span{{"epoch", 0, 10, "1332151919"}}, e.Spans)
+
+	// failures still have the steps taken
+	e, err = Explain("13/02/2020")
+	assert.NotEqual(t, nil, err)
+	assert.Equal(t, "dateDigitSlash", e.Steps[len(e.Steps)-1].DateState)
+
+	// the month/day swap retry is recorded, then the steps of the retry
+	e, err = Explain("13/02/2020", RetryAmbiguousDateWithSwap(true))
+	assert.Equal(t, nil, err)
+	retries := 0
+	for i, ev := range e.Steps {
+		if ev.Kind == TraceRetry {
+			retries++
+			assert.Equal(t, TraceEvent{Kind: TraceRetry, Input: "13/02/2020", DateState: "dateDigitSlash", TimeState: "timeIgnore"}, ev)
+			assert.Equal(t, TraceEvent{TraceSet, "13/02/2020", 0, '1', "dateDigitSlash", "timeIgnore", "02"}, e.Steps[i+3])
+		}
+	}
+	assert.Equal(t, 1, retries)
+	assert.Equal(t, "02/01/2006", e.Layout.Layout)
+}
+
+func TestWithTrace(t *testing.T) {
+	kinds := make(map[TraceKind]int)
+	var sets []string
+	_, err := ParseAny("Tue 05 May 2020, 05:05:05", WithTrace(func(ev TraceEvent) {
+		kinds[ev.Kind]++
+		if ev.Kind == TraceSet {
+			sets = append(sets, ev.Layout)
+		}
+	}))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, kinds[TraceRestart])
+	assert.Equal(t, 5, kinds[TraceTransition])
+	assert.Equal(t, []string{"02", "Jan", "2006", "2006", "15", "04", "05"}, sets)
+}
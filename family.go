@@ -0,0 +1,177 @@
+package dateparse
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Family is a set of date format families, see AllowFamilies.
+type Family uint16
+
+const (
+	// FamilyISO8601 are year-first dash dates, ie "2006-01-02",
+	// "2006-01-02T15:04:05Z07:00", "2006-01"
+	FamilyISO8601 Family = 1 << iota
+	// FamilyRFC1123 are weekday-comma dates of RFC 1123, 822 and 850, ie
+	// "Mon, 02 Jan 2006 15:04:05 MST", "Monday, 02-Jan-06 15:04:05 MST"
+	FamilyRFC1123
+	// FamilyANSIC are weekday month day dates of ANSIC, UnixDate, RubyDate
+	// and javascript's Date.toString, ie "Mon Jan _2 15:04:05 2006"
+	FamilyANSIC
+	// FamilySlash are numeric slash dates, ie "01/02/2006", "2006/01/02"
+	FamilySlash
+	// FamilyDot are numeric dot dates, ie "02.01.2006", "2006.01"
+	FamilyDot
+	// FamilyColon are numeric colon dates, ie "01:02:2006 15:04"
+	FamilyColon
+	// FamilyEpoch are integers of seconds, millis, micros or nanos since
+	// the unix epoch
+	FamilyEpoch
+	// FamilyCompact are digits without separators, ie "20060102",
+	// "20060102150405", "060102 15:04:05"
+	FamilyCompact
+	// FamilyYear is a bare 4 digit year, ie "2006"
+	FamilyYear
+	// FamilyCJK are chinese/japanese dates, ie "2006年01月02日"
+	FamilyCJK
+	// FamilyAlphaMonth are the other dates with a month name, ie
+	// "Jan 2, 2006", "2 January 2006", "02-Jan-2006", "02/Jan/2006:15:04:05"
+	FamilyAlphaMonth
+)
+
+// FamilyAll is every format family, the default.
+const FamilyAll = FamilyAlphaMonth<<1 - 1
+
+var familyNames = []string{
+	"iso8601",
+	"rfc1123",
+	"ansic",
+	"slash",
+	"dot",
+	"colon",
+	"epoch",
+	"compact",
+	"year",
+	"cjk",
+	"alpha-month",
+}
+
+// AllowFamilies is an option to only accept dates in the given format
+// families, others fail with a *FamilyError.  Use it to pin a service to
+// a documented set of input formats.
+//
+//	t, err := dateparse.ParseAny(s, dateparse.AllowFamilies(dateparse.FamilyISO8601|dateparse.FamilyRFC1123))
+func AllowFamilies(f Family) ParserOption {
+	return func(p *parser) error {
+		p.families = f
+		return nil
+	}
+}
+
+// DenyFamilies is an option to reject dates in the given format families,
+// accepting any other.
+//
+//	t, err := dateparse.ParseAny(s, dateparse.DenyFamilies(dateparse.FamilyEpoch|dateparse.FamilyYear))
+func DenyFamilies(f Family) ParserOption {
+	return func(p *parser) error {
+		p.families &^= f
+		return nil
+	}
+}
+
+// FamilyError is the error for a date in a format family that isn't
+// allowed, see AllowFamilies.
+type FamilyError struct {
+	Family Family
+	Input  string
+}
+
+func (e *FamilyError) Error() string {
+	return fmt.Sprintf("This date is in the %s format family, which is not allowed: %q", e.Family, e.Input)
+}
+
+func (f Family) String() string {
+	if f == FamilyAll {
+		return "all"
+	}
+	var names []string
+	for i, name := range familyNames {
+		if f&(1<<uint(i)) != 0 {
+			names = append(names, name)
+		}
+	}
+	return strings.Join(names, "|")
+}
+
+// MarshalText writes the families as their names, ie "iso8601|rfc1123"
+func (f Family) MarshalText() ([]byte, error) {
+	return []byte(f.String()), nil
+}
+
+// UnmarshalText reads names separated by "|" or ",", as written by
+// MarshalText, and also "all".
+func (f *Family) UnmarshalText(text []byte) error {
+	var v Family
+	for _, name := range strings.FieldsFunc(string(text), func(r rune) bool { return r == '|' || r == ',' }) {
+		name = strings.TrimSpace(name)
+		if name == "all" {
+			v |= FamilyAll
+			continue
+		}
+		found := false
+		for i, n := range familyNames {
+			if n == name {
+				v |= 1 << uint(i)
+				found = true
+			}
+		}
+		if !found {
+			return fmt.Errorf("unknown format family %q", name)
+		}
+	}
+	*f = v
+	return nil
+}
+
+// family is the format family of a detected date, from the state the
+// parser finished in.
+func (p *parser) family() Family {
+	switch p.stateDate {
+	case dateDigit:
+		switch {
+		case p.t != nil:
+			return FamilyEpoch
+		case len(p.format) == len("2006"):
+			return FamilyYear
+		}
+		return FamilyCompact
+	case dateDigitSt:
+		return FamilyCompact
+	case dateYearDash, dateYearDashDash, dateYearDashDashWs, dateYearDashDashT, dateYearDashDashOffset:
+		return FamilyISO8601
+	case dateDigitDot, dateDigitDotDot:
+		return FamilyDot
+	case dateDigitSlash, dateDigitYearSlash:
+		return FamilySlash
+	case dateDigitColon:
+		return FamilyColon
+	case dateDigitChineseYear, dateDigitChineseYearWs:
+		return FamilyCJK
+	case dateWeekdayComma, dateWeekdayAbbrevComma:
+		return FamilyRFC1123
+	}
+	// restarts drop a leading weekday, so look at the input
+	var words []TokenKind
+	for _, tok := range lex(p.input, 0, len(p.input), nil) {
+		if tok.Kind != TokenSeparator {
+			words = append(words, tok.Kind)
+		}
+		if len(words) == 2 {
+			break
+		}
+	}
+	if len(words) == 2 && words[0] == TokenWeekday && words[1] == TokenMonth {
+		return FamilyANSIC
+	}
+	return FamilyAlphaMonth
+}
@@ -0,0 +1,75 @@
+package dateparse
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFamily(t *testing.T) {
+	tests := []struct {
+		in     string
+		family Family
+	}{
+		{"2006-01-02", FamilyISO8601},
+		{"2014-04", FamilyISO8601},
+		{"2009-08-12T22:15:09-07:00", FamilyISO8601},
+		{"2020-07-20+08:00", FamilyISO8601},
+		{"Mon, 02 Jan 2006 15:04:05 MST", FamilyRFC1123},
+		{"Wednesday, 07-May-09 08:00:43 MST", FamilyRFC1123},
+		{"Mon Jan  2 15:04:05 2006", FamilyANSIC},
+		{"Mon Jan 02 15:04:05 -0700 2006", FamilyANSIC},
+		{"Fri Jul 03 2015 18:04:07 GMT+0100 (GMT Daylight Time)", FamilyANSIC},
+		{"03/31/2014", FamilySlash},
+		{"2014/04/02 04:08", FamilySlash},
+		{"3.31.2014", FamilyDot},
+		{"2014.05", FamilyDot},
+		{"04:02:2014 04:08", FamilyColon},
+		{"1332151919", FamilyEpoch},
+		{"1384216367111", FamilyEpoch},
+		{"20140601", FamilyCompact},
+		{"20140722105203", FamilyCompact},
+		{"171113 14:14:20", FamilyCompact},
+		{"2014", FamilyYear},
+		{"2014年04月08日", FamilyCJK},
+		{"2014年04月08日 19:17:22", FamilyCJK},
+		{"May 8, 2009 5:57:51 PM", FamilyAlphaMonth},
+		{"7 oct 1970", FamilyAlphaMonth},
+		{"Mon 02 Jan 2006 03:04:05 PM UTC", FamilyAlphaMonth},
+		{"2013-Feb-03", FamilyAlphaMonth},
+		{"07-Feb-2004 09:07:07 +0100", FamilyAlphaMonth},
+		{"06/May/2008:08:11:17 -0700", FamilyAlphaMonth},
+		{"September 17th, 2012", FamilyAlphaMonth},
+	}
+	for _, tc := range tests {
+		_, err := ParseAny(tc.in, AllowFamilies(tc.family))
+		assert.Equal(t, nil, err, tc.in)
+		_, err = ParseAny(tc.in, DenyFamilies(tc.family))
+		assert.Equal(t, &FamilyError{Family: tc.family, Input: tc.in}, err, tc.in)
+	}
+
+	// the swap retry is checked too
+	_, err := ParseAny("13/02/2020", RetryAmbiguousDateWithSwap(true), AllowFamilies(FamilyISO8601))
+	assert.Equal(t, &FamilyError{Family: FamilySlash, Input: "13/02/2020"}, err)
+	_, err = ParseFormat("2014", DenyFamilies(FamilyYear|FamilyEpoch))
+	assert.Equal(t, `This date is in the year format family, which is not allowed: "2014"`, err.Error())
+	_, err = ParseLayout("1332151919", DenyFamilies(FamilyEpoch))
+	assert.NotEqual(t, nil, err)
+
+	res := ParseMany([]string{"2006-01-02", "2006-01-03", "1332151919"}, DenyFamilies(FamilyEpoch))
+	assert.Equal(t, 1, res.Failed)
+}
+
+func TestFamilyText(t *testing.T) {
+	b, err := (FamilyISO8601 | FamilyAlphaMonth).MarshalText()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "iso8601|alpha-month", string(b))
+	assert.Equal(t, "all", FamilyAll.String())
+
+	var f Family
+	assert.Equal(t, nil, f.UnmarshalText([]byte("rfc1123, ansic")))
+	assert.Equal(t, FamilyRFC1123|FamilyANSIC, f)
+	assert.Equal(t, nil, f.UnmarshalText([]byte("all")))
+	assert.Equal(t, FamilyAll, f)
+	assert.NotEqual(t, nil, f.UnmarshalText([]byte("julian")))
+}
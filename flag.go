@@ -0,0 +1,68 @@
+package dateparse
+
+import (
+	"flag"
+	"time"
+)
+
+// TimeValue is a flag.Value holding a time parsed from any format dateparse
+// understands, ie --since "2020-01-02", --since "Jan 2", --since 1332151919.
+// It also has the Type method pflag expects, so it can be passed to
+// pflag's FlagSet.Var.
+type TimeValue struct {
+	// Time the parsed value is written to
+	Time *time.Time
+	// Location for values without zone/offset info, same rules as ParseIn.
+	// nil uses ParseAny rules.
+	Location *time.Location
+	// Options passed to the parser, ie PreferMonthFirst(false)
+	Options []ParserOption
+}
+
+// NewTimeValue returns a TimeValue writing to p, which is set to value.
+func NewTimeValue(p *time.Time, value time.Time, loc *time.Location, opts ...ParserOption) *TimeValue {
+	*p = value
+	return &TimeValue{Time: p, Location: loc, Options: opts}
+}
+
+// Set implements flag.Value
+func (v *TimeValue) Set(s string) error {
+	t, err := ParseIn(s, v.Location, v.Options...)
+	if err != nil {
+		return err
+	}
+	*v.Time = t
+	return nil
+}
+
+// String implements flag.Value, zero times are empty so the flag package
+// doesn't print them as a default.
+func (v *TimeValue) String() string {
+	if v == nil || v.Time == nil || v.Time.IsZero() {
+		return ""
+	}
+	return v.Time.Format(time.RFC3339Nano)
+}
+
+// Get implements flag.Getter
+func (v *TimeValue) Get() interface{} {
+	return *v.Time
+}
+
+// Type is the value type name used by pflag in usage output.
+func (v *TimeValue) Type() string {
+	return "time"
+}
+
+// TimeVar defines a time flag on fs (flag.CommandLine when nil), with the
+// given name, default value and usage, storing the parsed value in p.
+//
+//	var since time.Time
+//	dateparse.TimeVar(nil, &since, "since", time.Time{}, "only show entries after this date", time.Local)
+//	flag.Parse()
+func TimeVar(fs *flag.FlagSet, p *time.Time, name string, value time.Time, usage string, loc *time.Location, opts ...ParserOption) {
+	if fs == nil {
+		fs = flag.CommandLine
+	}
+	fs.Var(NewTimeValue(p, value, loc, opts...), name, usage)
+}
@@ -0,0 +1,52 @@
+package dateparse
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeVar(t *testing.T) {
+	time.Local = time.UTC
+	denverLoc, err := time.LoadLocation("America/Denver")
+	assert.Equal(t, nil, err)
+
+	var since, until time.Time
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(&bytes.Buffer{})
+	TimeVar(fs, &since, "since", time.Time{}, "start date", denverLoc)
+	TimeVar(fs, &until, "until", time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), "end date", nil, PreferMonthFirst(false))
+
+	err = fs.Parse([]string{"--since", "2020-01-02", "--until", "03/02/2020"})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2020-01-02 00:00:00 -0700 MST", fmt.Sprintf("%v", since))
+	assert.Equal(t, "2020-02-03 00:00:00 +0000 UTC", fmt.Sprintf("%v", until))
+
+	err = fs.Parse([]string{"--since", "1332151919"})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2012-03-19 10:11:59 +0000 UTC", fmt.Sprintf("%v", since.In(time.UTC)))
+	assert.Equal(t, "2012-03-19T04:11:59-06:00", fs.Lookup("since").Value.String())
+
+	err = fs.Parse([]string{"--since", "not a date"})
+	assert.NotEqual(t, nil, err)
+
+	// zero defaults are left out of usage, others shown
+	var usage bytes.Buffer
+	fs2 := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs2.SetOutput(&usage)
+	var a, b time.Time
+	TimeVar(fs2, &a, "a", time.Time{}, "a date", nil)
+	TimeVar(fs2, &b, "b", time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), "b date", nil)
+	fs2.PrintDefaults()
+	assert.Equal(t, false, strings.Contains(usage.String(), "0001"))
+	assert.Equal(t, true, strings.Contains(usage.String(), `(default 2020-01-01T00:00:00Z)`))
+
+	v := fs.Lookup("until").Value.(*TimeValue)
+	assert.Equal(t, "time", v.Type())
+	assert.Equal(t, until, v.Get())
+}
@@ -0,0 +1,283 @@
+package dateparse
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LayoutKind says how a Layout reads a date string.
+type LayoutKind uint8
+
+const (
+	// LayoutGo is a go reference-time layout string
+	LayoutGo LayoutKind = iota
+	// LayoutEpochSeconds is an integer of seconds since the unix epoch
+	LayoutEpochSeconds
+	// LayoutEpochMillis is an integer of milliseconds since the unix epoch
+	LayoutEpochMillis
+	// LayoutEpochMicros is an integer of microseconds since the unix epoch
+	LayoutEpochMicros
+	// LayoutEpochNanos is an integer of nanoseconds since the unix epoch
+	LayoutEpochNanos
+)
+
+var layoutKindNames = []string{
+	LayoutGo:           "layout",
+	LayoutEpochSeconds: "epoch-s",
+	LayoutEpochMillis:  "epoch-ms",
+	LayoutEpochMicros:  "epoch-us",
+	LayoutEpochNanos:   "epoch-ns",
+}
+
+// epochUnits is the duration of one unit of each epoch kind.
+var epochUnits = []time.Duration{
+	LayoutEpochSeconds: time.Second,
+	LayoutEpochMillis:  time.Millisecond,
+	LayoutEpochMicros:  time.Microsecond,
+	LayoutEpochNanos:   time.Nanosecond,
+}
+
+func (k LayoutKind) String() string {
+	if int(k) < len(layoutKindNames) {
+		return layoutKindNames[k]
+	}
+	return fmt.Sprintf("LayoutKind(%d)", k)
+}
+
+// MarshalText writes the kind as its name, ie "epoch-ms"
+func (k LayoutKind) MarshalText() ([]byte, error) {
+	if int(k) >= len(layoutKindNames) {
+		return nil, fmt.Errorf("unknown layout kind %d", k)
+	}
+	return []byte(k.String()), nil
+}
+
+// UnmarshalText reads a kind written by MarshalText
+func (k *LayoutKind) UnmarshalText(text []byte) error {
+	for i, name := range layoutKindNames {
+		if name == string(text) {
+			*k = LayoutKind(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown layout kind %q", text)
+}
+
+// LayoutFlags record the guesses and input handling behind a Layout.
+type LayoutFlags uint16
+
+const (
+	// LayoutAmbiguous the date could be read mm/dd or dd/mm (or yy-mon-dd
+	// vs dd-mon-yy), see ParseStrict
+	LayoutAmbiguous LayoutFlags = 1 << iota
+	// LayoutDayFirst an ambiguous date was read day first, ie PreferMonthFirst(false)
+	// or a RetryAmbiguousDateWithSwap swap
+	LayoutDayFirst
+	// LayoutTwoDigitYear the year is 2 digits, go picks the century
+	LayoutTwoDigitYear
+	// LayoutNoYear there is no year in the layout, so it parses as year 0
+	LayoutNoYear
+	// LayoutZoneAbbrev the zone is an abbreviation such as MST, whose
+	// meaning depends on the location used to parse
+	LayoutZoneAbbrev
+	// LayoutRewritten the input had to be altered before the go layout
+	// applied (weekday prefix or trailing text dropped, ordinal suffix
+	// removed, "," fractions etc)
+	LayoutRewritten
+	// LayoutUTC the date ends in a (Z)ulu "Z", so it is in UTC whatever
+	// the location, go reads the Z as text
+	LayoutUTC
+)
+
+var layoutFlagNames = []string{
+	"ambiguous",
+	"day-first",
+	"two-digit-year",
+	"no-year",
+	"zone-abbrev",
+	"rewritten",
+	"utc",
+}
+
+func (f LayoutFlags) String() string {
+	var names []string
+	for i, name := range layoutFlagNames {
+		if f&(1<<uint(i)) != 0 {
+			names = append(names, name)
+		}
+	}
+	return strings.Join(names, "|")
+}
+
+// Layout describes a detected date format, and can re-apply exactly that
+// format to other date strings.  It is safe to persist (ie as json) and
+// re-use later.
+type Layout struct {
+	// Kind of format, a go layout or an epoch integer
+	Kind LayoutKind `json:"kind"`
+	// Layout is the go layout string, empty for epoch kinds
+	Layout string `json:"layout,omitempty"`
+	// Order of the year, month and day fields, ie "YMD", "MDY", "DMY"
+	Order string `json:"order,omitempty"`
+	// Flags about guesses made detecting the layout
+	Flags LayoutFlags `json:"flags,omitempty"`
+}
+
+// ParseLayout parses an unknown date-time string and returns a Layout
+// describing its format, which can parse other date-time strings of the
+// exact same format.
+//
+//	l, err := dateparse.ParseLayout("03/31/2014")
+//	// l.Layout = "01/02/2006", l.Order = "MDY", l.Flags = LayoutAmbiguous
+//	t, err := l.Parse("04/01/2014", nil)
+func ParseLayout(datestr string, opts ...ParserOption) (Layout, error) {
+	_, l, err := ParseLayoutIn(datestr, nil, opts...)
+	return l, err
+}
+
+// ParseLayoutIn parses datestr like ParseIn, also returning the Layout
+// it was in, so both come from one parse.
+func ParseLayoutIn(datestr string, loc *time.Location, opts ...ParserOption) (time.Time, Layout, error) {
+	p, err := parseTime(datestr, loc, opts...)
+	if err != nil {
+		return time.Time{}, Layout{}, err
+	}
+	t, err := p.parse()
+	if err != nil {
+		return time.Time{}, Layout{}, err
+	}
+	return t, p.layout(datestr), nil
+}
+
+// layout describes the format found by a parser, which must already have
+// been parse()'d.
+func (p *parser) layout(datestr string) Layout {
+	if p.t != nil {
+		// the state machine only hands back epochs by their digit count
+		switch len(p.datestr) {
+		case len("1332151919"):
+			return Layout{Kind: LayoutEpochSeconds}
+		case len("1332151919000"):
+			return Layout{Kind: LayoutEpochMillis}
+		case len("1499979795437000"):
+			return Layout{Kind: LayoutEpochMicros}
+		default:
+			return Layout{Kind: LayoutEpochNanos}
+		}
+	}
+	l := Layout{Kind: LayoutGo, Layout: string(p.format)}
+	if p.ambiguousMD {
+		l.Flags |= LayoutAmbiguous
+		if !p.preferMonthFirst {
+			l.Flags |= LayoutDayFirst
+		}
+	}
+	if p.datestr != datestr {
+		l.Flags |= LayoutRewritten
+	}
+	if p.zulu() {
+		l.Flags |= LayoutUTC
+	}
+	hasYear := false
+	for _, c := range layoutChunks(l.Layout) {
+		switch c.elem {
+		case elemLongYear, elemYear:
+			hasYear = true
+			l.Order += "Y"
+			if c.elem == elemYear {
+				l.Flags |= LayoutTwoDigitYear
+			}
+		case elemLongMonth, elemMonth, elemNumMonth, elemZeroMonth:
+			l.Order += "M"
+		case elemDay, elemUnderDay, elemZeroDay:
+			l.Order += "D"
+		case elemTZ:
+			l.Flags |= LayoutZoneAbbrev
+		}
+	}
+	if !hasYear {
+		l.Flags |= LayoutNoYear
+	}
+	return l
+}
+
+// Parse parses datestr using exactly this layout.  Location rules are the
+// same as ParseIn, a nil loc behaves like ParseAny, and layouts flagged
+// LayoutUTC are in UTC.
+//
+// Layouts flagged LayoutRewritten need the same input rewriting as when
+// they were detected, so datestr is run through the parser again (with
+// the same month/day preference) and must come out with this layout.
+func (l Layout) Parse(datestr string, loc *time.Location) (time.Time, error) {
+	if l.Kind != LayoutGo {
+		if int(l.Kind) >= len(epochUnits) {
+			return time.Time{}, fmt.Errorf("unknown layout kind %d", l.Kind)
+		}
+		n, err := strconv.ParseInt(datestr, 10, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("%q is not a %v timestamp", datestr, l.Kind)
+		}
+		t := time.Unix(0, n*int64(epochUnits[l.Kind]))
+		if l.Kind == LayoutEpochSeconds {
+			t = time.Unix(n, 0)
+		}
+		if loc != nil {
+			t = t.In(loc)
+		}
+		return t, nil
+	}
+	if l.Flags&LayoutRewritten == 0 {
+		if l.Flags&LayoutUTC != 0 {
+			loc = time.UTC
+		}
+		if loc == nil {
+			return time.Parse(l.Layout, datestr)
+		}
+		return time.ParseInLocation(l.Layout, datestr, loc)
+	}
+	p, err := parseTime(datestr, loc, PreferMonthFirst(l.Flags&LayoutDayFirst == 0))
+	if err != nil {
+		return time.Time{}, err
+	}
+	t, err := p.parse()
+	if err != nil {
+		return time.Time{}, err
+	}
+	if p.t != nil || string(p.format) != l.Layout {
+		return time.Time{}, fmt.Errorf("%q does not match layout %q", datestr, l.Layout)
+	}
+	return t, nil
+}
+
+// Format renders t in this layout.
+func (l Layout) Format(t time.Time) string {
+	if l.Kind != LayoutGo && int(l.Kind) < len(epochUnits) {
+		return strconv.FormatInt(t.UnixNano()/int64(epochUnits[l.Kind]), 10)
+	}
+	return t.Format(l.Layout)
+}
+
+// Convert renders the layout as a pattern of another Dialect, see
+// ConvertLayout.
+func (l Layout) Convert(d Dialect) (string, error) {
+	switch {
+	case l.Kind == LayoutGo:
+		return ConvertLayout(l.Layout, d)
+	case l.Kind == LayoutEpochSeconds && d == DialectStrftime:
+		return "%s", nil
+	case l.Kind == LayoutEpochSeconds && d == DialectMoment:
+		return "X", nil
+	case l.Kind == LayoutEpochMillis && d == DialectMoment:
+		return "x", nil
+	}
+	return "", fmt.Errorf("%v timestamps can not be represented in %v", l.Kind, d)
+}
+
+func (l Layout) String() string {
+	if l.Kind == LayoutGo {
+		return l.Layout
+	}
+	return l.Kind.String()
+}
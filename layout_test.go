@@ -0,0 +1,123 @@
+package dateparse
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type layoutTest struct {
+	in, layout, order string
+	kind              LayoutKind
+	flags             LayoutFlags
+	next, out         string
+}
+
+var testLayouts = []layoutTest{
+	{in: "2013-02-01 00:00:00", layout: "2006-01-02 15:04:05", order: "YMD", next: "2014-03-31 10:11:59", out: "2014-03-31 10:11:59 +0000 UTC"},
+	{in: "03/31/2014", layout: "01/02/2006", order: "MDY", flags: LayoutAmbiguous, next: "04/01/2014", out: "2014-04-01 00:00:00 +0000 UTC"},
+	{in: "3/1/14", layout: "1/2/06", order: "MDY", flags: LayoutAmbiguous | LayoutTwoDigitYear, next: "4/2/15", out: "2015-04-02 00:00:00 +0000 UTC"},
+	{in: "Mon Jan  2 15:04:05 MST 2006", layout: "Jan  2 15:04:05 MST 2006", order: "MDY", flags: LayoutZoneAbbrev | LayoutRewritten, next: "Thu May  8 17:57:51 MST 2009", out: "2009-05-08 17:57:51 +0000 UTC"},
+	{in: "Monday, 02 Jan 2006 15:04:05 -0700", layout: "02 Jan 2006 15:04:05 -0700", order: "DMY", flags: LayoutRewritten, next: "Wednesday, 08 May 2009 17:57:51 -0700", out: "2009-05-09 00:57:51 +0000 UTC"},
+	{in: "2017-07-19T03:22:00.123Z", layout: "2006-01-02T15:04:05.000Z", order: "YMD", flags: LayoutUTC, next: "2018-01-02T10:11:12.456Z", out: "2018-01-02 10:11:12.456 +0000 UTC"},
+	{in: "1332151919", kind: LayoutEpochSeconds, next: "1384216367", out: "2013-11-12 00:32:47 +0000 UTC"},
+	{in: "1384216367111", kind: LayoutEpochMillis, next: "999999999999", out: "2001-09-09 01:46:39.999 +0000 UTC"},
+	{in: "1384216367111222", kind: LayoutEpochMicros, next: "1384216367111222", out: "2013-11-12 00:32:47.111222 +0000 UTC"},
+	{in: "1384216367111222333", kind: LayoutEpochNanos, next: "1384216367111222333", out: "2013-11-12 00:32:47.111222333 +0000 UTC"},
+}
+
+func TestLayoutDescriptor(t *testing.T) {
+	time.Local = time.UTC
+	for _, th := range testLayouts {
+		l, err := ParseLayout(th.in)
+		assert.Equal(t, nil, err, "%q", th.in)
+		assert.Equal(t, th.kind, l.Kind, "%q", th.in)
+		assert.Equal(t, th.layout, l.Layout, "%q", th.in)
+		assert.Equal(t, th.order, l.Order, "%q", th.in)
+		assert.Equal(t, th.flags, l.Flags, "%q got %v", th.in, l.Flags)
+
+		ts, err := l.Parse(th.next, nil)
+		assert.Equal(t, nil, err, "%q", th.next)
+		assert.Equal(t, th.out, fmt.Sprintf("%v", ts.In(time.UTC)), "%q", th.next)
+
+		// persisted layouts read back the same
+		b, err := json.Marshal(l)
+		assert.Equal(t, nil, err)
+		var back Layout
+		assert.Equal(t, nil, json.Unmarshal(b, &back))
+		assert.Equal(t, l, back)
+	}
+
+	_, err := ParseLayout("INVALID")
+	assert.NotEqual(t, nil, err)
+}
+
+func TestLayoutParse(t *testing.T) {
+	time.Local = time.UTC
+
+	l, err := ParseLayout("04/02/2014", PreferMonthFirst(false))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "02/01/2006", l.Layout)
+	assert.Equal(t, "DMY", l.Order)
+	assert.Equal(t, LayoutAmbiguous|LayoutDayFirst, l.Flags)
+	assert.Equal(t, "ambiguous|day-first", l.Flags.String())
+
+	// only the exact format
+	_, err = l.Parse("2014-02-04", nil)
+	assert.NotEqual(t, nil, err)
+
+	// rewritten layouts must be detected again as the same layout
+	l, err = ParseLayout("Monday, 02 Jan 2006 15:04:05 -0700")
+	assert.Equal(t, nil, err)
+	_, err = l.Parse("Monday, 02 Jan 2006 15:04:05", nil)
+	assert.NotEqual(t, nil, err)
+
+	// epochs are read in the detected unit whatever their length
+	l, err = ParseLayout("1332151919")
+	assert.Equal(t, nil, err)
+	ts, err := l.Parse("1", nil)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int64(1), ts.Unix())
+	assert.Equal(t, "1332151919", l.Format(MustParse("1332151919")))
+	assert.Equal(t, "epoch-s", l.String())
+	_, err = l.Parse("2014-02-04", nil)
+	assert.NotEqual(t, nil, err)
+
+	denverLoc, err := time.LoadLocation("America/Denver")
+	assert.Equal(t, nil, err)
+	l, err = ParseLayout("2013-02-01 00:00:00")
+	assert.Equal(t, nil, err)
+	ts, err = l.Parse("2013-02-01 00:00:00", denverLoc)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2013-02-01 07:00:00 +0000 UTC", fmt.Sprintf("%v", ts.In(time.UTC)))
+	assert.Equal(t, "2013-02-01 00:00:00", l.Format(ts))
+	assert.Equal(t, "2006-01-02 15:04:05", l.String())
+
+	// a Z is UTC in any location, as the parser reads it
+	for _, in := range []string{"2017-07-19T03:22:00.123Z", "2017-07-19T03:22:00Z"} {
+		l, err = ParseLayout(in)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, "utc", l.Flags.String())
+		want, err := ParseIn(in, denverLoc)
+		assert.Equal(t, nil, err)
+		ts, err = l.Parse(in, denverLoc)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, want, ts, in)
+		assert.Equal(t, "2017-07-19 03:22:00 +0000 UTC", fmt.Sprintf("%v", ts.Truncate(time.Second)))
+	}
+
+	// the time in the location, and its layout, from one parse
+	ts, l, err = ParseLayoutIn("2013-02-01 00:00:00", denverLoc)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2013-02-01 07:00:00 +0000 UTC", fmt.Sprintf("%v", ts.In(time.UTC)))
+	assert.Equal(t, "2006-01-02 15:04:05", l.Layout)
+	_, l, err = ParseLayoutIn("INVALID", denverLoc)
+	assert.NotEqual(t, nil, err)
+	assert.Equal(t, Layout{}, l)
+
+	var k LayoutKind
+	assert.NotEqual(t, nil, k.UnmarshalText([]byte("epoch-days")))
+}
@@ -0,0 +1,146 @@
+package dateparse
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// fillerWords are dropped anywhere in a lenient date, ie "on Jan 2 2020"
+var fillerWords = map[string]bool{
+	"on":  true,
+	"at":  true,
+	"the": true,
+	"of":  true,
+}
+
+// wrappingPairs are the quotes and brackets stripped from around a
+// lenient date
+var wrappingPairs = map[rune]rune{
+	'"':  '"',
+	'\'': '\'',
+	'`':  '`',
+	'“':  '”',
+	'‘':  '’',
+	'«':  '»',
+	'(':  ')',
+	'[':  ']',
+	'{':  '}',
+	'<':  '>',
+}
+
+// strayPunctuation is dropped from either end of a lenient date
+const strayPunctuation = ".,;:!?"
+
+// Lenient is an option to Clean the date string before parsing it, so
+// that wrapped, worded or punctuated dates such as "[2020-01-02 10:00]",
+// "(Jan 2, 2020)" or "on Jan 2, 2020." parse.  Explain reports what was
+// ignored.
+func Lenient(lenient bool) ParserOption {
+	return func(p *parser) error {
+		p.lenient = lenient
+		return nil
+	}
+}
+
+// Clean strips what the Lenient option ignores from a date string:
+// wrapping quotes and brackets, filler words (on, at, the, of), stray
+// punctuation at either end, a redundant "UTC" after "Z", and runs of
+// whitespace.  The returned spans are what was ignored, as offsets into
+// datestr, with Field "wrapping", "filler", "punctuation", "zone" or
+// "space".
+//
+//	s, ignored := dateparse.Clean(`"on Jan 2, 2020."`)
+//	// s = "Jan 2, 2020", ignored = `"` `on` ` ` `.` `"`
+func Clean(datestr string) (string, []Span) {
+	var ignored []Span
+	ignore := func(field string, start, end int) {
+		ignored = append(ignored, Span{Field: field, Start: start, End: end, Text: datestr[start:end]})
+	}
+
+	// peel the ends until there is nothing left to strip
+	lo, hi := 0, len(datestr)
+	for changed := true; changed && lo < hi; {
+		changed = false
+		if end := runEnd(datestr[:hi], lo, unicode.IsSpace); end > lo {
+			ignore("space", lo, end)
+			lo, changed = end, true
+		}
+		if start := runStart(datestr[:hi], lo, unicode.IsSpace); start < hi {
+			ignore("space", start, hi)
+			hi, changed = start, true
+		}
+		if start := runStart(datestr[:hi], lo, isStrayPunctuation); start < hi {
+			ignore("punctuation", start, hi)
+			hi, changed = start, true
+		}
+		if end := runEnd(datestr[:hi], lo, isStrayPunctuation); end > lo {
+			ignore("punctuation", lo, end)
+			lo, changed = end, true
+		}
+		first, fsize := utf8.DecodeRuneInString(datestr[lo:hi])
+		last, lsize := utf8.DecodeLastRuneInString(datestr[lo:hi])
+		if closing, ok := wrappingPairs[first]; ok && last == closing && hi-lo >= fsize+lsize {
+			ignore("wrapping", lo, lo+fsize)
+			ignore("wrapping", hi-lsize, hi)
+			lo, hi, changed = lo+fsize, hi-lsize, true
+		}
+	}
+
+	var sb strings.Builder
+	// whitespace runs since the last token written, collapsed to a space
+	// before the next one
+	var spaces []Span
+	toks := lex(datestr[lo:hi], 0, hi-lo, nil)
+	for i, tok := range toks {
+		start, end := lo+tok.Start, lo+tok.End
+		switch {
+		case tok.Kind == TokenWord && fillerWords[strings.ToLower(tok.Text)]:
+			ignore("filler", start, end)
+			continue
+		case tok.Kind == TokenZone && (strings.EqualFold(tok.Text, "UTC") || strings.EqualFold(tok.Text, "GMT")) &&
+			i >= 2 && toks[i-1].Kind == TokenSeparator && toks[i-2].Text == "Z":
+			// 2020-01-02T10:00:00Z UTC
+			ignore("zone", start, end)
+			continue
+		case tok.Kind == TokenSeparator && strings.TrimFunc(tok.Text, unicode.IsSpace) == "":
+			spaces = append(spaces, Span{Field: "space", Start: start, End: end, Text: tok.Text})
+			continue
+		}
+		for j, sp := range spaces {
+			if j == 0 && sb.Len() > 0 {
+				sb.WriteByte(' ')
+				if sp.Text == " " {
+					continue
+				}
+			}
+			ignored = append(ignored, sp)
+		}
+		spaces = spaces[:0]
+		sb.WriteString(tok.Text)
+	}
+	ignored = append(ignored, spaces...)
+	sort.SliceStable(ignored, func(i, j int) bool {
+		return ignored[i].Start < ignored[j].Start
+	})
+	return sb.String(), ignored
+}
+
+// runStart is the offset of the run of runes ending at s[len(s)] matching
+// fn, not before lo
+func runStart(s string, lo int, fn func(rune) bool) int {
+	i := len(s)
+	for i > lo {
+		r, size := utf8.DecodeLastRuneInString(s[lo:i])
+		if !fn(r) {
+			break
+		}
+		i -= size
+	}
+	return i
+}
+
+func isStrayPunctuation(r rune) bool {
+	return strings.ContainsRune(strayPunctuation, r)
+}
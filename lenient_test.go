@@ -0,0 +1,77 @@
+package dateparse
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClean(t *testing.T) {
+	tests := []struct {
+		in      string
+		out     string
+		ignored []string
+	}{
+		{`"2020-01-02"`, "2020-01-02", []string{`"`, `"`}},
+		{"[2020-01-02 10:00:00]", "2020-01-02 10:00:00", []string{"[", "]"}},
+		{"(Jan 2, 2020)", "Jan 2, 2020", []string{"(", ")"}},
+		{"on Jan 2, 2020", "Jan 2, 2020", []string{"on", " "}},
+		{"2020-01-02.", "2020-01-02", []string{"."}},
+		{"2020-01-02T10:00:00Z UTC", "2020-01-02T10:00:00Z", []string{" ", "UTC"}},
+		{`  "on the 2 Jan of 2020."  `, "2 Jan 2020", []string{"  ", `"`, "on", " ", "the", " ", "of", " ", ".", `"`, "  "}},
+		{"Jan\t2  2020", "Jan 2 2020", []string{"\t", "  "}},
+		{"2020-01-02 10:00 at", "2020-01-02 10:00", []string{" ", "at"}},
+		{"«2 janvier 2020»", "2 janvier 2020", []string{"«", "»"}},
+		// not wrapped, the parser drops it
+		{"Fri Jul 03 2015 18:04:07 GMT+0100 (GMT Daylight Time)", "Fri Jul 03 2015 18:04:07 GMT+0100 (GMT Daylight Time)", nil},
+		{"2020-01-02", "2020-01-02", nil},
+		{`"`, `"`, nil},
+	}
+	for _, tc := range tests {
+		out, ignored := Clean(tc.in)
+		assert.Equal(t, tc.out, out, tc.in)
+		var texts []string
+		for _, sp := range ignored {
+			assert.Equal(t, tc.in[sp.Start:sp.End], sp.Text, tc.in)
+			texts = append(texts, sp.Text)
+		}
+		assert.Equal(t, tc.ignored, texts, tc.in)
+	}
+}
+
+func TestLenient(t *testing.T) {
+	for _, in := range []string{
+		`"2020-01-02 10:00:00"`,
+		"[2020-01-02 10:00:00]",
+		"(Jan 2, 2020 10:00)",
+		"on Jan 2, 2020 at 10:00",
+		"2020-01-02 10:00:00.",
+		"2020-01-02T10:00:00Z UTC",
+		"'Thursday, 2 Jan 2020 10:00:00'",
+	} {
+		_, err := ParseAny(in)
+		if in != "2020-01-02 10:00:00." && in != "2020-01-02T10:00:00Z UTC" {
+			assert.NotEqual(t, nil, err, in)
+		}
+		ts, err := ParseAny(in, Lenient(true))
+		assert.Equal(t, nil, err, in)
+		assert.Equal(t, "2020-01-02 10:00:00 +0000 UTC", ts.In(time.UTC).String(), in)
+	}
+
+	e, err := Explain("[on Jan 2, 2020]", Lenient(true))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "Jan 2, 2020", e.Parsed)
+	assert.Equal(t, []Span{
+		{Field: "wrapping", Start: 0, End: 1, Text: "["},
+		{Field: "filler", Start: 1, End: 3, Text: "on"},
+		{Field: "space", Start: 3, End: 4, Text: " "},
+		{Field: "wrapping", Start: 15, End: 16, Text: "]"},
+	}, e.Ignored)
+	assert.Equal(t, true, e.Layout.Flags&LayoutRewritten != 0)
+
+	// a restart (the weekday is dropped) keeps what was ignored
+	e, err = Explain(`"Thursday, 2 Jan 2020"`, Lenient(true))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 2, len(e.Ignored))
+}
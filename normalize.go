@@ -0,0 +1,89 @@
+package dateparse
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Normalize maps the unicode variants of what the parser reads to ascii:
+// decimal digits of every script ("٢٠٢٠", full-width "２０２０") to 0-9,
+// spaces (no-break, thin, ideographic etc) to ' ', dashes (hyphen, figure
+// dash, en dash, minus sign etc) to '-', and other full-width ascii
+// ("：", "／", "Ｔ") to ascii.  Other runes, ie CJK units, are kept.
+// offsets[i] is the offset in datestr of byte i of the result, with
+// offsets[len(result)] = len(datestr), to map spans back to the input.
+// The parser normalizes every input.
+func Normalize(datestr string) (string, []int) {
+	ascii := true
+	for i := 0; i < len(datestr); i++ {
+		if datestr[i] >= utf8.RuneSelf {
+			ascii = false
+			break
+		}
+	}
+	if ascii {
+		return datestr, nil
+	}
+	var sb strings.Builder
+	offsets := make([]int, 0, len(datestr)+1)
+	changed := false
+	for i, r := range datestr {
+		if n := normalRune(r); n != r {
+			sb.WriteRune(n)
+			offsets = append(offsets, i)
+			changed = true
+			continue
+		}
+		// as is, which keeps invalid utf8 bytes
+		_, size := utf8.DecodeRuneInString(datestr[i:])
+		sb.WriteString(datestr[i : i+size])
+		for j := 0; j < size; j++ {
+			offsets = append(offsets, i+j)
+		}
+	}
+	if !changed {
+		return datestr, nil
+	}
+	return sb.String(), append(offsets, len(datestr))
+}
+
+// normalRune is the ascii rune r stands for, or r.
+func normalRune(r rune) rune {
+	switch {
+	case r < utf8.RuneSelf:
+		return r
+	case unicode.IsDigit(r):
+		return '0' + digitValue(r)
+	case unicode.IsSpace(r):
+		return ' '
+	case r == '−' || unicode.Is(unicode.Pd, r):
+		return '-'
+	case r >= '！' && r <= '～':
+		// full-width ascii
+		return r - '！' + '!'
+	}
+	return r
+}
+
+// digitValue is the value of the decimal digit r, unicode puts each
+// script's digits in a run from 0 to 9 (some scripts have several runs
+// back to back).
+func digitValue(r rune) rune {
+	zero := r
+	for unicode.IsDigit(zero - 1) {
+		zero--
+	}
+	return (r - zero) % 10
+}
+
+// inputSpan maps a span of a normalized string back to datestr, see
+// Normalize.
+func inputSpan(datestr string, offsets []int, sp Span) Span {
+	if offsets == nil {
+		return sp
+	}
+	sp.Start, sp.End = offsets[sp.Start], offsets[sp.End]
+	sp.Text = datestr[sp.Start:sp.End]
+	return sp
+}
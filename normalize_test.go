@@ -0,0 +1,78 @@
+package dateparse
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		in  string
+		out string
+	}{
+		{"2020-01-02", "2020-01-02"},
+		{"٢٠٢٠-٠١-٠٢", "2020-01-02"},
+		{"２０２０－０１－０２", "2020-01-02"},
+		{"२०२०-०१-०२ १०:३०", "2020-01-02 10:30"},
+		{"۱۳۹۹/۱۰/۱۲", "1399/10/12"},
+		{"𝟐𝟎𝟐𝟎-𝟘𝟙-𝟶𝟸", "2020-01-02"},
+		{"2020‐01‑02", "2020-01-02"},
+		{"2020‒01–02—10:00", "2020-01-02-10:00"},
+		{"10:00:00−07:00", "10:00:00-07:00"},
+		{"Jan 2, 2020 2pm　UTC", "Jan 2, 2020 2pm UTC"},
+		{"10：30 ２０２０／０１／０２Ｔ", "10:30 2020/01/02T"},
+		{"２０１４年０４月０８日", "2014年04月08日"},
+		{"2014年04月08日", "2014年04月08日"},
+		{"2020-01-02\xff", "2020-01-02\xff"},
+		{"\xff２", "\xff2"},
+	}
+	for _, tc := range tests {
+		out, offsets := Normalize(tc.in)
+		assert.Equal(t, tc.out, out, tc.in)
+		if out == tc.in {
+			assert.Equal(t, []int(nil), offsets, tc.in)
+			continue
+		}
+		assert.Equal(t, len(out)+1, len(offsets), tc.in)
+		assert.Equal(t, len(tc.in), offsets[len(out)], tc.in)
+	}
+}
+
+func TestParseNormalized(t *testing.T) {
+	for _, in := range []string{
+		"٢٠٢٠-٠١-٠٢ ١٠:٠٠",
+		"２０２０－０１－０２ １０：００",
+		"2020‐01‐02 10:00",
+		"2020-01-02 10:00",
+		"Jan 2, 2020 10:00",
+		"２０２０年０１月０２日 10:00:00",
+	} {
+		ts, err := ParseAny(in)
+		assert.Equal(t, nil, err, in)
+		assert.Equal(t, "2020-01-02 10:00:00 +0000 UTC", ts.String(), in)
+	}
+	ts, err := ParseAny("2020–01–02T10:00:00−07:00")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2020-01-02T10:00:00-07:00", ts.Format("2006-01-02T15:04:05Z07:00"))
+
+	// spans point into the input
+	e, err := Explain("２０２０－０１－０２ 10:00")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2020-01-02 10:00", e.Parsed)
+	assert.Equal(t, []Span{
+		{Field: "year", Start: 0, End: 12, Text: "２０２０"},
+		{Field: "month", Start: 15, End: 21, Text: "０１"},
+		{Field: "day", Start: 24, End: 30, Text: "０２"},
+		{Field: "hour", Start: 31, End: 33, Text: "10"},
+		{Field: "minute", Start: 34, End: 36, Text: "00"},
+	}, e.Spans)
+
+	// and so does what Lenient ignored
+	e, err = Explain("«on ２０２０－０１－０２»", Lenient(true))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, Span{Field: "filler", Start: 2, End: 4, Text: "on"}, e.Ignored[1])
+
+	toks := Tokenize("٢٠٢٠")
+	assert.Equal(t, 2020, toks[0].Value)
+}
@@ -0,0 +1,308 @@
+package dateparse
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Candidate is one reading of a date string, see ParseAll.
+type Candidate struct {
+	Layout Layout    `json:"layout"`
+	Time   time.Time `json:"time"`
+	// Score is a heuristic for how likely this reading is, from 0 to 1,
+	// the reading ParseIn would give is usually highest.
+	Score float64 `json:"score"`
+	// Reading describes the guesses behind it, ie "day-first",
+	// "yy-mon-dd", "1900s" or "CST as China"
+	Reading string `json:"reading"`
+}
+
+// zoneReading is one meaning of a zone abbreviation
+type zoneReading struct {
+	name   string
+	offset int
+}
+
+// zoneAbbrevs are common zone abbreviations and what they may mean, the
+// most widely used first.
+var zoneAbbrevs = map[string][]zoneReading{
+	"ACST": {{"Australian Central", 9*3600 + 1800}},
+	"ADT":  {{"Atlantic Daylight", -3 * 3600}},
+	"AEDT": {{"Australian Eastern Daylight", 11 * 3600}},
+	"AEST": {{"Australian Eastern", 10 * 3600}},
+	"AKDT": {{"Alaska Daylight", -8 * 3600}},
+	"AKST": {{"Alaska", -9 * 3600}},
+	"AST":  {{"Atlantic", -4 * 3600}, {"Arabia", 3 * 3600}},
+	"AWST": {{"Australian Western", 8 * 3600}},
+	"BST":  {{"British Summer", 3600}, {"Bangladesh", 6 * 3600}},
+	"CAT":  {{"Central Africa", 2 * 3600}},
+	"CDT":  {{"US Central Daylight", -5 * 3600}, {"Cuba Daylight", -4 * 3600}},
+	"CEST": {{"Central European Summer", 2 * 3600}},
+	"CET":  {{"Central European", 3600}},
+	"CST":  {{"US Central", -6 * 3600}, {"China", 8 * 3600}, {"Cuba", -5 * 3600}},
+	"EAT":  {{"East Africa", 3 * 3600}},
+	"EDT":  {{"US Eastern Daylight", -4 * 3600}},
+	"EEST": {{"Eastern European Summer", 3 * 3600}},
+	"EET":  {{"Eastern European", 2 * 3600}},
+	"EST":  {{"US Eastern", -5 * 3600}},
+	"GST":  {{"Gulf", 4 * 3600}, {"South Georgia", -2 * 3600}},
+	"HKT":  {{"Hong Kong", 8 * 3600}},
+	"HST":  {{"Hawaii", -10 * 3600}},
+	"IST":  {{"India", 5*3600 + 1800}, {"Irish", 3600}, {"Israel", 2 * 3600}},
+	"JST":  {{"Japan", 9 * 3600}},
+	"KST":  {{"Korea", 9 * 3600}},
+	"MDT":  {{"US Mountain Daylight", -6 * 3600}},
+	"MSK":  {{"Moscow", 3 * 3600}},
+	"MST":  {{"US Mountain", -7 * 3600}},
+	"NZDT": {{"New Zealand Daylight", 13 * 3600}},
+	"NZST": {{"New Zealand", 12 * 3600}},
+	"PDT":  {{"US Pacific Daylight", -7 * 3600}},
+	"PKT":  {{"Pakistan", 5 * 3600}},
+	"PST":  {{"US Pacific", -8 * 3600}, {"Philippine", 8 * 3600}},
+	"SAST": {{"South Africa", 2 * 3600}},
+	"SGT":  {{"Singapore", 8 * 3600}},
+	"WAT":  {{"West Africa", 3600}},
+	"WEST": {{"Western European Summer", 3600}},
+	"WET":  {{"Western European", 0}},
+}
+
+// ParseAll parses an unknown date string into every plausible reading of
+// it, best first: month-first and day-first, dd-mon-yy and yy-mon-dd,
+// either century of a two digit year, and the common meanings of a zone
+// abbreviation.  Unambiguous input gives one Candidate.  The error is
+// that of ParseAny when there is no reading at all.
+//
+//	cs, err := dateparse.ParseAll("03/04/05 10:00 CST")
+//	for _, c := range cs {
+//		fmt.Printf("%.2f %s %s\n", c.Score, c.Time, c.Reading)
+//	}
+func ParseAll(datestr string, opts ...ParserOption) ([]Candidate, error) {
+	return ParseAllIn(datestr, nil, opts...)
+}
+
+// ParseAllIn is ParseAll with a location, same rules as ParseIn.
+func ParseAllIn(datestr string, loc *time.Location, opts ...ParserOption) ([]Candidate, error) {
+	preferMonthFirst := newParser(datestr, loc, opts...).preferMonthFirst
+
+	var cs []Candidate
+	var firstErr error
+	for _, monthFirst := range []bool{preferMonthFirst, !preferMonthFirst} {
+		// each order is read separately, without a retry swapping it
+		orderOpts := append(opts[:len(opts):len(opts)], PreferMonthFirst(monthFirst), RetryAmbiguousDateWithSwap(false))
+		p, err := parseTime(datestr, loc, orderOpts...)
+		var t time.Time
+		if err == nil {
+			t, err = p.parse()
+		}
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		l := p.layout(datestr)
+		score := 1.0
+		if monthFirst != preferMonthFirst {
+			if l.Flags&LayoutAmbiguous == 0 || len(cs) > 0 && cs[0].Layout.Layout == l.Layout {
+				// the preference doesn't apply
+				continue
+			}
+			score = 0.5
+			if len(cs) == 0 {
+				// the preferred order is out of range
+				score = 0.9
+			}
+		}
+		reading := ""
+		if l.Flags&LayoutAmbiguous != 0 {
+			reading = "dd-mon-yy"
+			if hasNumericMonth(l.Layout) {
+				reading = "month-first"
+				if !monthFirst {
+					reading = "day-first"
+				}
+			}
+		}
+		cs = append(cs, Candidate{Layout: l, Time: t, Score: score, Reading: reading})
+		if alt, ok := dayYearSwap(p, l, loc); ok {
+			alt.Score = score * 0.4
+			cs = append(cs, alt)
+		}
+	}
+	if len(cs) == 0 {
+		return nil, firstErr
+	}
+
+	for _, c := range cs {
+		if c.Layout.Flags&LayoutTwoDigitYear != 0 {
+			cs = append(cs, otherCentury(c))
+		}
+	}
+	if abbrev := zoneAbbrev(datestr); abbrev != "" {
+		for _, c := range cs {
+			if c.Layout.Flags&LayoutZoneAbbrev != 0 {
+				cs = append(cs, zoneReadings(c, abbrev)...)
+			}
+		}
+		for i := range cs {
+			if cs[i].Layout.Flags&LayoutZoneAbbrev != 0 && isFabricatedZone(cs[i].Time, abbrev) {
+				// go didn't know the abbreviation and read it as UTC
+				cs[i].Score *= 0.3
+				cs[i].Reading = joinReading(cs[i].Reading, abbrev+" as UTC")
+			}
+		}
+	}
+
+	sort.SliceStable(cs, func(i, j int) bool {
+		return cs[i].Score > cs[j].Score
+	})
+	// the same instant may be reached by several readings, ie 03/03/2020
+	out := cs[:0]
+	for _, c := range cs {
+		dup := false
+		for _, o := range out {
+			if o.Time.Equal(c.Time) {
+				dup = true
+				break
+			}
+		}
+		if !dup {
+			out = append(out, c)
+		}
+	}
+	return out, nil
+}
+
+// dayYearSwap is the yy-mon-dd reading of a date the parser read as
+// dd-mon-yy (ie "13-Feb-03").
+func dayYearSwap(p *parser, l Layout, loc *time.Location) (Candidate, bool) {
+	if l.Kind != LayoutGo || l.Flags&LayoutAmbiguous == 0 || l.Flags&LayoutTwoDigitYear == 0 {
+		return Candidate{}, false
+	}
+	chunks := layoutChunks(l.Layout)
+	day, year := -1, -1
+	for i, c := range chunks {
+		switch c.elem {
+		case elemZeroDay:
+			day = i
+		case elemYear:
+			year = i
+		}
+	}
+	if hasNumericMonth(l.Layout) || day < 0 || year < 0 || day > year {
+		return Candidate{}, false
+	}
+	chunks[day].elem, chunks[year].elem = elemYear, elemZeroDay
+	layout, ok := chunksLayout(chunks)
+	if !ok {
+		return Candidate{}, false
+	}
+	var t time.Time
+	var err error
+	if loc == nil {
+		t, err = time.Parse(layout, p.datestr)
+	} else {
+		t, err = time.ParseInLocation(layout, p.datestr, loc)
+	}
+	if err != nil {
+		return Candidate{}, false
+	}
+	swapped := l
+	swapped.Layout = layout
+	swapped.Order = strings.Replace(strings.Replace(strings.Replace(l.Order, "D", "x", 1), "Y", "D", 1), "x", "Y", 1)
+	return Candidate{Layout: swapped, Time: t, Reading: "yy-mon-dd"}, true
+}
+
+// hasNumericMonth is true if the layout's month is a number, so its
+// ambiguity is the month/day order
+func hasNumericMonth(layout string) bool {
+	for _, c := range layoutChunks(layout) {
+		if c.elem == elemNumMonth || c.elem == elemZeroMonth {
+			return true
+		}
+	}
+	return false
+}
+
+// otherCentury is c with its two digit year in the century go didn't
+// pick (go reads 69-99 as 1900s, 00-68 as 2000s).
+func otherCentury(c Candidate) Candidate {
+	years := 100
+	reading := "2000s"
+	if c.Time.Year() >= 2000 {
+		years = -100
+		reading = "1900s"
+	}
+	return Candidate{
+		Layout:  c.Layout,
+		Time:    c.Time.AddDate(years, 0, 0),
+		Score:   c.Score * 0.25,
+		Reading: joinReading(c.Reading, reading),
+	}
+}
+
+// zoneAbbrev is the last known zone abbreviation in datestr
+func zoneAbbrev(datestr string) string {
+	abbrev := ""
+	for _, tok := range lex(datestr, 0, len(datestr), nil) {
+		if tok.Kind == TokenZone {
+			if _, ok := zoneAbbrevs[strings.ToUpper(tok.Text)]; ok {
+				abbrev = strings.ToUpper(tok.Text)
+			}
+		}
+	}
+	return abbrev
+}
+
+// zoneReadings are c with the same wall clock in each known meaning of
+// abbrev its offset doesn't already have.
+func zoneReadings(c Candidate, abbrev string) []Candidate {
+	var cs []Candidate
+	_, offset := c.Time.Zone()
+	fabricated := isFabricatedZone(c.Time, abbrev)
+	for i, z := range zoneAbbrevs[abbrev] {
+		if z.offset == offset && !fabricated {
+			continue
+		}
+		score := 0.3
+		if fabricated {
+			// the table is a better guess than UTC
+			score = 0.5
+			if i == 0 {
+				score = 0.8
+			}
+		}
+		t := c.Time
+		cs = append(cs, Candidate{
+			Layout:  c.Layout,
+			Time:    time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.FixedZone(abbrev, z.offset)),
+			Score:   c.Score * score,
+			Reading: joinReading(c.Reading, fmt.Sprintf("%s as %s", abbrev, z.name)),
+		})
+	}
+	return cs
+}
+
+// isFabricatedZone is true if t has the zero offset go gives zone
+// abbreviations it doesn't know in the location.
+func isFabricatedZone(t time.Time, abbrev string) bool {
+	name, offset := t.Zone()
+	if offset != 0 || name != abbrev {
+		return false
+	}
+	for _, z := range zoneAbbrevs[abbrev] {
+		if z.offset == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func joinReading(a, b string) string {
+	if a == "" {
+		return b
+	}
+	return a + ", " + b
+}
@@ -0,0 +1,83 @@
+package dateparse
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// readings are the candidates as "time reading" strings, best first
+func readings(cs []Candidate) []string {
+	var out []string
+	for _, c := range cs {
+		out = append(out, c.Time.Format(time.RFC3339)+" "+c.Reading)
+	}
+	return out
+}
+
+func TestParseAll(t *testing.T) {
+	time.Local = time.UTC
+
+	cs, err := ParseAll("2020-01-02 10:11:12")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"2020-01-02T10:11:12Z "}, readings(cs))
+	assert.Equal(t, 1.0, cs[0].Score)
+	assert.Equal(t, "2006-01-02 15:04:05", cs[0].Layout.Layout)
+
+	cs, err = ParseAll("03/04/2020")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"2020-03-04T00:00:00Z month-first", "2020-04-03T00:00:00Z day-first"}, readings(cs))
+	assert.Equal(t, "02/01/2006", cs[1].Layout.Layout)
+
+	cs, err = ParseAll("03/04/2020", PreferMonthFirst(false))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"2020-04-03T00:00:00Z day-first", "2020-03-04T00:00:00Z month-first"}, readings(cs))
+
+	// only one order is in range
+	cs, err = ParseAll("13/02/2020")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"2020-02-13T00:00:00Z day-first"}, readings(cs))
+
+	// the same date either way
+	cs, err = ParseAll("03/03/2020")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, len(cs))
+
+	cs, err = ParseAll("13-Feb-03")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{
+		"2003-02-13T00:00:00Z dd-mon-yy",
+		"2013-02-03T00:00:00Z yy-mon-dd",
+		"1903-02-13T00:00:00Z dd-mon-yy, 1900s",
+		"1913-02-03T00:00:00Z yy-mon-dd, 1900s",
+	}, readings(cs))
+	assert.Equal(t, "06-Jan-02", cs[1].Layout.Layout)
+	assert.Equal(t, "YMD", cs[1].Layout.Order)
+
+	cs, err = ParseAll("Mon Jan  2 15:04:05 IST 2006")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{
+		"2006-01-02T15:04:05+05:30 IST as India",
+		"2006-01-02T15:04:05+01:00 IST as Irish",
+		"2006-01-02T15:04:05+02:00 IST as Israel",
+		"2006-01-02T15:04:05Z IST as UTC",
+	}, readings(cs))
+
+	// a location that knows the abbreviation reads it first
+	denverLoc, err := time.LoadLocation("America/Denver")
+	assert.Equal(t, nil, err)
+	cs, err = ParseAllIn("12 Feb 2006, 19:17 MST", denverLoc)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"2006-02-12T19:17:00-07:00 "}, readings(cs))
+	cs, err = ParseAllIn("12 Feb 2006, 19:17 CST", denverLoc)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2006-02-12T19:17:00-06:00 CST as US Central", readings(cs)[0])
+
+	for _, c := range cs {
+		assert.True(t, c.Score > 0 && c.Score <= 1, c.Reading)
+	}
+
+	_, err = ParseAll("nope")
+	assert.NotEqual(t, nil, err)
+}
@@ -221,6 +221,7 @@ func ParseFormat(datestr string, opts ...ParserOption) (string, error) {
 
 // ParseStrict parse an unknown date format.  IF the date is ambigous
 // mm/dd vs dd/mm then return an error. These return errors:   3.3.2014 , 8/8/71 etc
+// Pass Strict(...) options to also reject the parser's other guesses.
 func ParseStrict(datestr string, opts ...ParserOption) (time.Time, error) {
 	p, err := parseTime(datestr, nil, opts...)
 	if err != nil {
@@ -235,6 +236,56 @@ func ParseStrict(datestr string, opts ...ParserOption) (time.Time, error) {
 func parseTime(datestr string, loc *time.Location, opts ...ParserOption) (p *parser, err error) {
 
 	p = newParser(datestr, loc, opts...)
+	if normalized, offsets := Normalize(datestr); offsets != nil {
+		datestr = normalized
+		p = newParser(datestr, loc, append(opts[:len(opts):len(opts)], fromParser(p))...)
+		p.offsets = offsets
+	}
+	if p.lenient {
+		if cleaned, ignored := Clean(datestr); len(ignored) > 0 {
+			datestr = cleaned
+			p = newParser(datestr, loc, append(opts[:len(opts):len(opts)], fromParser(p))...)
+			for i := range ignored {
+				ignored[i] = inputSpan(p.input, p.offsets, ignored[i])
+			}
+			p.ignored = ignored
+		}
+	}
+	if p.families != FamilyAll {
+		// deferred before the retry below, so it sees the swapped parser
+		defer func() {
+			if err == nil && p != nil {
+				if f := p.family(); p.families&f == 0 {
+					p, err = nil, &FamilyError{Family: f, Input: p.input}
+				}
+			}
+		}()
+	}
+	if spoken, clock := spokenHints(datestr); spoken || clock {
+		// spoken dates ("the 3rd of March at noon") are rewritten only if
+		// they don't parse as they are, deferred before the retry so it
+		// has had its go.  Dates with a time word always are, it parses as
+		// literal text.
+		orig := p
+		defer func() {
+			if err == nil && p != nil && !clock {
+				trace, strict := p.trace, p.strict
+				p.trace, p.strict = nil, 0
+				t, perr := p.parse()
+				p.trace, p.strict = trace, strict
+				if perr == nil {
+					// the caller's parse() re-uses it
+					p.parsed = &t
+					return
+				}
+			}
+			if spoken, ok := spokenDate(datestr); ok && spoken != datestr {
+				if sp, serr := orig.restart(spoken, loc, opts...); serr == nil {
+					p, err = sp, nil
+				}
+			}
+		}()
+	}
 	if p.retryAmbiguousDateWithSwap {
 		// month out of range signifies that a day/month swap is the correct solution to an ambiguous date
 		// this is because it means that a day is being interpreted as a month and overflowing the valid value for that
@@ -243,14 +294,13 @@ func parseTime(datestr string, loc *time.Location, opts ...ParserOption) (p *par
 			if p != nil && p.ambiguousMD {
 				// if it errors out with the following error, swap before we
 				// get out of this function to reduce scope it needs to be applied on
-				_, err := p.parse()
-				if err != nil && strings.Contains(err.Error(), "month out of range") {
-					// create the option to reverse the preference
-					preferMonthFirst := PreferMonthFirst(!p.preferMonthFirst)
-					// turn off the retry to avoid endless recursion
-					retryAmbiguousDateWithSwap := RetryAmbiguousDateWithSwap(false)
-					modifiedOpts := append(opts, preferMonthFirst, retryAmbiguousDateWithSwap)
-					p, err = parseTime(datestr, time.Local, modifiedOpts...)
+				// (untraced, the caller parses again)
+				trace := p.trace
+				p.trace = nil
+				_, perr := p.parse()
+				p.trace = trace
+				if perr != nil && strings.Contains(perr.Error(), "month out of range") {
+					p, err = p.retry(datestr, loc, opts...)
 				}
 			}
 
@@ -267,6 +317,7 @@ iterRunes:
 	for ; i < len(datestr); i++ {
 		//r := rune(datestr[i])
 		r, bytesConsumed := utf8.DecodeRuneInString(datestr[i:])
+		p.traceRune(i, r)
 		if bytesConsumed > 1 {
 			i += (bytesConsumed - 1)
 		}
@@ -802,7 +853,7 @@ iterRunes:
 					maybeDay := strings.ToLower(datestr[0:i])
 					if isDay(maybeDay) {
 						// using skip throws off indices used by other code; saner to restart
-						return parseTime(datestr[i+1:], loc)
+						return p.restart(datestr[i+1:], loc, opts...)
 					}
 					p.stateDate = dateAlphaWs
 				}
@@ -831,7 +882,7 @@ iterRunes:
 				} else if i == 4 {
 					// gross
 					datestr = datestr[0:i-1] + datestr[i:]
-					return parseTime(datestr, loc, opts...)
+					return p.restart(datestr, loc, opts...)
 				} else {
 					return nil, unknownErr(datestr)
 				}
@@ -986,25 +1037,25 @@ iterRunes:
 			case 't', 'T':
 				if p.nextIs(i, 'h') || p.nextIs(i, 'H') {
 					if len(datestr) > i+2 {
-						return parseTime(fmt.Sprintf("%s%s", p.datestr[0:i], p.datestr[i+2:]), loc, opts...)
+						return p.restart(fmt.Sprintf("%s%s", p.datestr[0:i], p.datestr[i+2:]), loc, opts...)
 					}
 				}
 			case 'n', 'N':
 				if p.nextIs(i, 'd') || p.nextIs(i, 'D') {
 					if len(datestr) > i+2 {
-						return parseTime(fmt.Sprintf("%s%s", p.datestr[0:i], p.datestr[i+2:]), loc, opts...)
+						return p.restart(fmt.Sprintf("%s%s", p.datestr[0:i], p.datestr[i+2:]), loc, opts...)
 					}
 				}
 			case 's', 'S':
 				if p.nextIs(i, 't') || p.nextIs(i, 'T') {
 					if len(datestr) > i+2 {
-						return parseTime(fmt.Sprintf("%s%s", p.datestr[0:i], p.datestr[i+2:]), loc, opts...)
+						return p.restart(fmt.Sprintf("%s%s", p.datestr[0:i], p.datestr[i+2:]), loc, opts...)
 					}
 				}
 			case 'r', 'R':
 				if p.nextIs(i, 'd') || p.nextIs(i, 'D') {
 					if len(datestr) > i+2 {
-						return parseTime(fmt.Sprintf("%s%s", p.datestr[0:i], p.datestr[i+2:]), loc, opts...)
+						return p.restart(fmt.Sprintf("%s%s", p.datestr[0:i], p.datestr[i+2:]), loc, opts...)
 					}
 				}
 			}
@@ -1111,6 +1162,7 @@ iterRunes:
 			break iterRunes
 		}
 	}
+	p.traceStates()
 	p.coalesceDate(i)
 	if p.stateTime == timeStart {
 		// increment first one, since the i++ occurs at end of loop
@@ -1128,6 +1180,7 @@ iterRunes:
 	iterTimeRunes:
 		for ; i < len(datestr); i++ {
 			r := rune(datestr[i])
+			p.traceRune(i, r)
 
 			// gou.Debugf("i=%d r=%s state=%d iterTimeRunes  %s %s", i, string(r), p.stateTime, p.ds(), p.ts())
 
@@ -1178,7 +1231,7 @@ iterRunes:
 					// 2014-05-11 08:20:13,787
 					ds := []byte(p.datestr)
 					ds[i] = '.'
-					return parseTime(string(ds), loc, opts...)
+					return p.restart(string(ds), loc, opts...)
 				case '-', '+':
 					//   03:21:51+00:00
 					p.stateTime = timeOffset
@@ -1512,6 +1565,11 @@ iterRunes:
 					p.mslen = i - p.msi
 					p.offseti = i
 					p.stateTime = timePeriodOffset
+				case 'Z':
+					// 15:04:05.99Z
+					p.mslen = i - p.msi
+					p.stateTime = timeZ
+					p.loc = time.UTC
 				default:
 					if unicode.IsLetter(r) {
 						// 06:20:00.000 UTC
@@ -1642,6 +1700,7 @@ iterRunes:
 
 			}
 		}
+		p.traceStates()
 
 		switch p.stateTime {
 		case timeWsAlpha:
@@ -1687,7 +1746,12 @@ iterRunes:
 			}
 
 		case timeWsOffset:
-			p.set(p.offseti, "-0700")
+			if len(p.datestr)-p.offseti == 3 {
+				// 19:17 +01
+				p.set(p.offseti, "-07")
+			} else {
+				p.set(p.offseti, "-0700")
+			}
 		case timeWsOffsetWs:
 			// 17:57:51 -0700 2009
 			// 00:12:00 +0000 UTC
@@ -1986,6 +2050,26 @@ type parser struct {
 	tzi                        int
 	tzlen                      int
 	t                          *time.Time
+	parallel                   int
+	trace                      func(TraceEvent)
+	tracedDate                 dateState
+	tracedTime                 timeState
+	tracei                     int
+	tracer                     rune
+	strict                     Strictness
+	families                   Family
+	lenient                    bool
+	// ignored by a Lenient Clean of the input
+	ignored []Span
+	// offsets of the normalized input in input, see Normalize
+	offsets []int
+	// input is the datestr before any restart rewrote it
+	input string
+	// trailing text dropped by trimExtra
+	trailing string
+	// parsed is the time of a parse() parseTime already made, before
+	// strict checks
+	parsed *time.Time
 }
 
 // ParserOption defines a function signature implemented by options
@@ -2013,6 +2097,8 @@ func newParser(dateStr string, loc *time.Location, opts ...ParserOption) *parser
 		stateDate:                  dateStart,
 		stateTime:                  timeIgnore,
 		datestr:                    dateStr,
+		input:                      dateStr,
+		families:                   FamilyAll,
 		loc:                        loc,
 		preferMonthFirst:           true,
 		retryAmbiguousDateWithSwap: false,
@@ -2026,6 +2112,12 @@ func newParser(dateStr string, loc *time.Location, opts ...ParserOption) *parser
 	return p
 }
 
+// zulu is true if the parser read a (Z)ulu "Z" after the time, which
+// it parses in UTC
+func (p *parser) zulu() bool {
+	return p.stateTime == timeZ || p.stateTime == timeZDigit
+}
+
 func (p *parser) nextIs(i int, b byte) bool {
 	if len(p.datestr) > i+1 && p.datestr[i+1] == b {
 		return true
@@ -2040,6 +2132,9 @@ func (p *parser) set(start int, val string) {
 	if len(p.format) < start+len(val) {
 		return
 	}
+	if p.trace != nil {
+		p.traceSet(start, val)
+	}
 	for i, r := range val {
 		p.format[start+i] = byte(r)
 	}
@@ -2123,9 +2218,7 @@ func (p *parser) coalesceTime(end int) {
 	}
 
 	if p.msi > 0 {
-		for i := 0; i < p.mslen; i++ {
-			p.format[p.msi+i] = '0'
-		}
+		p.set(p.msi, strings.Repeat("0", p.mslen))
 	}
 }
 func (p *parser) setFullMonth(month string) {
@@ -2136,6 +2229,7 @@ func (p *parser) setFullMonth(month string) {
 
 func (p *parser) trimExtra() {
 	if p.extra > 0 && len(p.format) > p.extra {
+		p.trailing = p.datestr[p.extra:]
 		p.format = p.format[0:p.extra]
 		p.datestr = p.datestr[0:p.extra]
 	}
@@ -2154,22 +2248,40 @@ func (p *parser) trimExtra() {
 
 func (p *parser) parse() (time.Time, error) {
 	if p.t != nil {
+		if p.strict&StrictEpoch != 0 {
+			return time.Time{}, ErrEpoch
+		}
 		return *p.t, nil
 	}
+	// once only, the retries parse more than once
 	if len(p.fullMonth) > 0 {
 		p.setFullMonth(p.fullMonth)
+		p.fullMonth = ""
 	}
 	if p.skip > 0 && len(p.format) > p.skip {
 		p.format = p.format[p.skip:]
 		p.datestr = p.datestr[p.skip:]
+		p.skip = 0
 	}
 
-	if p.loc == nil {
+	var t time.Time
+	var err error
+	if p.parsed != nil {
+		t = *p.parsed
+	} else if p.loc == nil {
 		// gou.Debugf("parse layout=%q input=%q   \ntx, err := time.Parse(%q, %q)", string(p.format), p.datestr, string(p.format), p.datestr)
-		return time.Parse(string(p.format), p.datestr)
+		t, err = time.Parse(string(p.format), p.datestr)
+	} else {
+		//gou.Debugf("parse layout=%q input=%q   \ntx, err := time.ParseInLocation(%q, %q, %v)", string(p.format), p.datestr, string(p.format), p.datestr, p.loc)
+		t, err = time.ParseInLocation(string(p.format), p.datestr, p.loc)
+	}
+	if err != nil || p.strict == 0 {
+		return t, err
+	}
+	if err = p.checkStrict(t); err != nil {
+		return time.Time{}, err
 	}
-	//gou.Debugf("parse layout=%q input=%q   \ntx, err := time.ParseInLocation(%q, %q, %v)", string(p.format), p.datestr, string(p.format), p.datestr, p.loc)
-	return time.ParseInLocation(string(p.format), p.datestr, p.loc)
+	return t, nil
 }
 func isDay(alpha string) bool {
 	for _, day := range days {
@@ -158,6 +158,8 @@ var testInputs = []dateTest{
 	{in: "07 Feb 2004, 09:07:07 GMT", out: "2004-02-07 09:07:07 +0000 UTC"},
 	//  dd-mon-yyyy  12 Feb 2006, 19:17:08 +0100
 	{in: "07 Feb 2004, 09:07:07 +0100", out: "2004-02-07 08:07:07 +0000 UTC"},
+	{in: "07 Feb 2004, 09:07:07 +01", out: "2004-02-07 08:07:07 +0000 UTC"},
+	{in: "07 Feb 2004, 09:07 -03", out: "2004-02-07 12:07:00 +0000 UTC"},
 	//  dd-mon-yyyy   12-Feb-2006 19:17:08
 	{in: "07-Feb-2004 09:07:07 +0100", out: "2004-02-07 08:07:07 +0000 UTC"},
 	//  dd-mon-yy   12-Feb-2006 19:17:08
@@ -679,6 +681,11 @@ func TestInLocation(t *testing.T) {
 	assert.Equal(t, "MDT", zone, "Should have found zone = MDT %v", zone)
 	assert.Equal(t, "2013-04-01 06:00:00 +0000 UTC", fmt.Sprintf("%v", ts.In(time.UTC)))
 
+	// (Z)ulu after fractional seconds is still UTC, not the given location
+	ts, err = ParseIn("2017-07-19T03:22:00.123Z", denverLoc)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2017-07-19 03:22:00.123 +0000 UTC", fmt.Sprintf("%v", ts.In(time.UTC)))
+
 	// reset to UTC
 	time.Local = time.UTC
 
@@ -743,6 +750,14 @@ func TestPreferMonthFirst(t *testing.T) {
 	assert.Equal(t, "2014-02-04 04:08:09 +0000 UTC", fmt.Sprintf("%v", ts.In(time.UTC)))
 }
 
+func TestWeekdayPrefixOptions(t *testing.T) {
+	// the parse restarts after a weekday prefix, which used to drop the
+	// options and read this as April 2nd
+	ts, err := ParseAny("Tue 04/02/2014 04:08:09", PreferMonthFirst(false))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2014-02-04 04:08:09 +0000 UTC", fmt.Sprintf("%v", ts.In(time.UTC)))
+}
+
 func TestRetryAmbiguousDateWithSwap(t *testing.T) {
 	// default is false
 	_, err := ParseAny("13/02/2014 04:08:09 +0000 UTC")
@@ -759,3 +774,17 @@ func TestRetryAmbiguousDateWithSwap(t *testing.T) {
 	assert.Equal(t, nil, err)
 	assert.Equal(t, "2014-02-13 04:08:09 +0000 UTC", fmt.Sprintf("%v", ts.In(time.UTC)))
 }
+
+func TestRetryAmbiguousDateWithSwapLocation(t *testing.T) {
+	// the retry keeps the location it was given, rather than time.Local
+	denverLoc, err := time.LoadLocation("America/Denver")
+	assert.Equal(t, nil, err)
+	ts, err := ParseIn("13/02/2014 04:08:09", denverLoc, RetryAmbiguousDateWithSwap(true))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2014-02-13 11:08:09 +0000 UTC", fmt.Sprintf("%v", ts.In(time.UTC)))
+
+	// an offset in the date wins, as without the retry
+	ts, err = ParseIn("13/02/2014 04:08:09 +0000 UTC", denverLoc, RetryAmbiguousDateWithSwap(true))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2014-02-13 04:08:09 +0000 UTC", fmt.Sprintf("%v", ts.In(time.UTC)))
+}
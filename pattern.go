@@ -0,0 +1,920 @@
+package dateparse
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ConvertPattern converts a strftime, Java, ICU or moment.js pattern into
+// the equivalent go layout string.
+//
+//	layout, err := dateparse.ConvertPattern("%d/%m/%Y %H:%M", dateparse.DialectStrftime)
+//	// layout = "02/01/2006 15:04"
+//
+// Patterns using something a go layout can't express (day of year, week
+// numbers, epoch seconds, literal text that looks like a layout element)
+// return an error, use ParseWithPattern for those.
+func ConvertPattern(pattern string, d Dialect) (string, error) {
+	chunks, err := patternChunks(pattern, d)
+	if err != nil {
+		return "", err
+	}
+	layout, ok := chunksLayout(chunks)
+	if !ok {
+		return "", fmt.Errorf("pattern %q can not be represented as a go layout", pattern)
+	}
+	return layout, nil
+}
+
+// ParseWithPattern parses datestr using a known pattern written in the
+// given Dialect.  Equivalent Timezone rules as time.Parse().
+//
+//	t, err := dateparse.ParseWithPattern("31/03/2014 10:11", "%d/%m/%Y %H:%M", dateparse.DialectStrftime)
+//	t, err := dateparse.ParseWithPattern("2014-03-31T10:11:59.123+02:00", "yyyy-MM-dd'T'HH:mm:ss.SSSXXX", dateparse.DialectJava)
+//
+// Patterns that translate to a go layout are parsed by the time package,
+// the rest (day of year, week numbers, epoch seconds, quoted literals)
+// by a matcher in this package.  Java and ICU week based fields (Y, w, e
+// and c) follow ISO 8601, weeks start on Monday, which is day 1, as in
+// Monday first locales: in a US locale the same pattern numbers Sunday 1.
+func ParseWithPattern(datestr, pattern string, d Dialect) (time.Time, error) {
+	return ParseWithPatternIn(datestr, pattern, d, nil)
+}
+
+// ParseWithPatternIn is ParseWithPattern with a Location, equivalent to
+// time.ParseInLocation() timezone/offset rules.
+func ParseWithPatternIn(datestr, pattern string, d Dialect, loc *time.Location) (time.Time, error) {
+	chunks, err := patternChunks(pattern, d)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if layout, ok := chunksLayout(chunks); ok {
+		if loc == nil {
+			return time.Parse(layout, datestr)
+		}
+		return time.ParseInLocation(layout, datestr, loc)
+	}
+	m, err := matchChunks(chunks, datestr)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parsing %q as %q: %v", datestr, pattern, err)
+	}
+	t, err := m.time(loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parsing %q as %q: %v", datestr, pattern, err)
+	}
+	return t, nil
+}
+
+// patternChunks splits a pattern of any Dialect into layout chunks.
+func patternChunks(pattern string, d Dialect) ([]layoutChunk, error) {
+	var chunks []layoutChunk
+	var err error
+	switch d {
+	case DialectGo:
+		return layoutChunks(pattern), nil
+	case DialectStrftime:
+		chunks, err = strftimeChunks(pattern)
+	case DialectJava, DialectICU:
+		chunks, err = ldmlChunks(pattern, d)
+	case DialectMoment:
+		chunks, err = momentChunks(pattern)
+	default:
+		return nil, fmt.Errorf("unknown dialect %v", d)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return mergeChunks(chunks), nil
+}
+
+// mergeChunks joins adjacent literals, and moves a '.' or ',' literal in
+// front of fractional seconds into the fraction, which is where a go
+// layout keeps it.
+func mergeChunks(chunks []layoutChunk) []layoutChunk {
+	out := make([]layoutChunk, 0, len(chunks))
+	for _, c := range chunks {
+		n := len(out)
+		switch {
+		case c.elem == elemLiteral && n > 0 && out[n-1].elem == elemLiteral:
+			out[n-1].text += c.text
+			continue
+		case c.elem == elemFracSecond0 && c.text == "" && n > 0 && out[n-1].elem == elemLiteral:
+			lit := out[n-1].text
+			if sep := lit[len(lit)-1]; sep == '.' || sep == ',' {
+				c.text = string(sep)
+				if len(lit) == 1 {
+					out = out[:n-1]
+				} else {
+					out[n-1].text = lit[:len(lit)-1]
+				}
+			}
+		}
+		out = append(out, c)
+	}
+	return out
+}
+
+// chunksLayout renders chunks as a go layout.  It is only ok if every
+// chunk is a go layout element, and the layout reads back as the same
+// chunks (ie literal text doesn't contain anything go treats as an
+// element).
+func chunksLayout(chunks []layoutChunk) (string, bool) {
+	var sb strings.Builder
+	for _, c := range chunks {
+		switch c.elem {
+		case elemLiteral:
+			sb.WriteString(c.text)
+		case elemFracSecond0, elemFracSecond9:
+			if c.text == "" {
+				return "", false
+			}
+			digit := "0"
+			if c.elem == elemFracSecond9 {
+				digit = "9"
+			}
+			sb.WriteString(c.text[:1] + strings.Repeat(digit, c.digits))
+		case elemUnderYearDay, elemZeroYearDay:
+			// not known to older go versions
+			return "", false
+		default:
+			tok, ok := dialectTokens[c.elem]
+			if !ok {
+				return "", false
+			}
+			sb.WriteString(tok[DialectGo])
+		}
+	}
+	layout := sb.String()
+	back := layoutChunks(layout)
+	if len(back) != len(chunks) {
+		return "", false
+	}
+	for i := range back {
+		if back[i].elem != chunks[i].elem || (back[i].elem == elemLiteral && back[i].text != chunks[i].text) {
+			return "", false
+		}
+	}
+	return layout, true
+}
+
+func strftimeChunks(pattern string) ([]layoutChunk, error) {
+	var chunks []layoutChunk
+	lit := func(s string) {
+		chunks = append(chunks, layoutChunk{elem: elemLiteral, text: s})
+	}
+	for i := 0; i < len(pattern); i++ {
+		if pattern[i] != '%' {
+			j := strings.IndexByte(pattern[i:], '%')
+			if j < 0 {
+				j = len(pattern) - i
+			}
+			lit(pattern[i : i+j])
+			i += j - 1
+			continue
+		}
+		start := i
+		i++
+		var flag, colon byte
+		if i < len(pattern) && strings.IndexByte("-_0^#", pattern[i]) >= 0 {
+			flag = pattern[i]
+			i++
+		}
+		if i < len(pattern) && pattern[i] == ':' {
+			colon = ':'
+			i++
+		}
+		if i >= len(pattern) {
+			return nil, fmt.Errorf("pattern %q ends with an incomplete directive", pattern)
+		}
+		var elem layoutElem
+		padded := func(zero, unpadded layoutElem) layoutElem {
+			if flag == '-' {
+				return unpadded
+			}
+			return zero
+		}
+		switch pattern[i] {
+		case 'Y':
+			elem = elemLongYear
+		case 'y':
+			elem = elemYear
+		case 'm':
+			elem = padded(elemZeroMonth, elemNumMonth)
+		case 'd':
+			elem = padded(elemZeroDay, elemDay)
+			if flag == '_' {
+				elem = elemUnderDay
+			}
+		case 'e':
+			elem = elemUnderDay
+		case 'j':
+			elem = elemZeroYearDay
+		case 'H', 'k':
+			elem = elemHour
+		case 'I':
+			elem = padded(elemZeroHour12, elemHour12)
+			if flag == '_' {
+				elem = elemUnderHour12
+			}
+		case 'l':
+			elem = padded(elemUnderHour12, elemHour12)
+		case 'M':
+			elem = padded(elemZeroMinute, elemMinute)
+		case 'S':
+			elem = padded(elemZeroSecond, elemSecond)
+		case 'f':
+			chunks = append(chunks, layoutChunk{elem: elemFracSecond0, digits: 6})
+			continue
+		case 'N':
+			chunks = append(chunks, layoutChunk{elem: elemFracSecond0, digits: 9})
+			continue
+		case 'p':
+			elem = elemPM
+		case 'P':
+			elem = elempm
+		case 'B':
+			elem = elemLongMonth
+		case 'b', 'h':
+			elem = elemMonth
+		case 'A':
+			elem = elemLongWeekDay
+		case 'a':
+			elem = elemWeekDay
+		case 'z':
+			elem = elemNumTZ
+			if colon == ':' {
+				elem = elemNumColonTZ
+			}
+		case 'Z':
+			elem = elemTZ
+		case 'G':
+			elem = elemISOYear
+		case 'V':
+			elem = elemISOWeek
+		case 'u':
+			elem = elemISOWeekDay
+		case 'w':
+			elem = elemNumWeekDay
+		case 'U':
+			elem = elemSundayWeek
+		case 'W':
+			elem = elemMondayWeek
+		case 's':
+			elem = elemUnixSeconds
+		case 'T', 'D', 'F', 'R', 'r':
+			expanded := map[byte]string{
+				'T': "%H:%M:%S",
+				'D': "%m/%d/%y",
+				'F': "%Y-%m-%d",
+				'R': "%H:%M",
+				'r': "%I:%M:%S %p",
+			}[pattern[i]]
+			sub, _ := strftimeChunks(expanded)
+			chunks = append(chunks, sub...)
+			continue
+		case '%':
+			lit("%")
+			continue
+		case 'n':
+			lit("\n")
+			continue
+		case 't':
+			lit("\t")
+			continue
+		default:
+			return nil, fmt.Errorf("pattern %q: directive %q is not supported", pattern, pattern[start:i+1])
+		}
+		chunks = append(chunks, layoutChunk{elem: elem, text: pattern[start : i+1]})
+	}
+	return chunks, nil
+}
+
+// ldmlChunks splits Java DateTimeFormatter and ICU (LDML) patterns, which
+// share most of their pattern letters.
+func ldmlChunks(pattern string, d Dialect) ([]layoutChunk, error) {
+	var chunks []layoutChunk
+	pad := 0
+	for i := 0; i < len(pattern); {
+		c := pattern[i]
+		if c == '\'' {
+			// quoted literal, '' is a single quote
+			if i+1 < len(pattern) && pattern[i+1] == '\'' {
+				chunks = append(chunks, layoutChunk{elem: elemLiteral, text: "'"})
+				i += 2
+				continue
+			}
+			var sb strings.Builder
+			j := i + 1
+			for ; j < len(pattern); j++ {
+				if pattern[j] == '\'' {
+					if j+1 < len(pattern) && pattern[j+1] == '\'' {
+						sb.WriteByte('\'')
+						j++
+						continue
+					}
+					break
+				}
+				sb.WriteByte(pattern[j])
+			}
+			if j >= len(pattern) {
+				return nil, fmt.Errorf("pattern %q has an unterminated quote", pattern)
+			}
+			chunks = append(chunks, layoutChunk{elem: elemLiteral, text: sb.String()})
+			i = j + 1
+			continue
+		}
+		if !isPatternRune(rune(c)) {
+			if d == DialectJava && strings.IndexByte("[]{}#", c) >= 0 {
+				return nil, fmt.Errorf("pattern %q: %q is not supported", pattern, string(c))
+			}
+			chunks = append(chunks, layoutChunk{elem: elemLiteral, text: string(c)})
+			i++
+			continue
+		}
+		n := 1
+		for i+n < len(pattern) && pattern[i+n] == c {
+			n++
+		}
+		tok := pattern[i : i+n]
+		i += n
+		if c == 'p' && d == DialectJava {
+			// pad modifier for the next field
+			pad = n
+			continue
+		}
+		var elem layoutElem
+		switch c {
+		case 'y', 'u':
+			elem = elemLongYear
+			if n == 2 {
+				elem = elemYear
+			}
+		case 'M', 'L':
+			elem = []layoutElem{elemNumMonth, elemZeroMonth, elemMonth, elemLongMonth, elemLiteral}[clampCount(n)]
+		case 'd':
+			elem = elemDay
+			if n == 2 {
+				elem = elemZeroDay
+			} else if pad == 2 {
+				elem = elemUnderDay
+			}
+		case 'D':
+			elem = elemUnderYearDay
+			if n == 3 {
+				elem = elemZeroYearDay
+			}
+		case 'E':
+			elem = elemWeekDay
+			if n == 4 {
+				elem = elemLongWeekDay
+			}
+		case 'e', 'c':
+			// local weekdays, numbered from Monday as in the ISO 8601 and
+			// Monday first locales, not Sunday as in the US
+			elem = []layoutElem{elemISOWeekDay, elemISOWeekDay, elemWeekDay, elemLongWeekDay, elemLiteral}[clampCount(n)]
+		case 'a':
+			elem = elemPM
+		case 'H':
+			elem = elemHour
+		case 'h':
+			elem = elemHour12
+			if n == 2 {
+				elem = elemZeroHour12
+			}
+		case 'm':
+			elem = elemMinute
+			if n == 2 {
+				elem = elemZeroMinute
+			}
+		case 's':
+			elem = elemSecond
+			if n == 2 {
+				elem = elemZeroSecond
+			}
+		case 'S':
+			chunks = append(chunks, layoutChunk{elem: elemFracSecond0, digits: n})
+			pad = 0
+			continue
+		case 'z':
+			if n < 4 {
+				elem = elemTZ
+			}
+		case 'Z':
+			if n < 4 {
+				elem = elemNumTZ
+			} else if n == 5 {
+				elem = elemISO8601ColonTZ
+			}
+		case 'X':
+			elem = []layoutElem{elemISO8601ShortTZ, elemISO8601TZ, elemISO8601ColonTZ, elemISO8601SecondsTZ, elemISO8601ColonSecondsTZ}[clampCount(n)]
+		case 'x':
+			elem = []layoutElem{elemNumShortTZ, elemNumTZ, elemNumColonTZ, elemNumSecondsTz, elemNumColonSecondsTZ}[clampCount(n)]
+		case 'Y':
+			if n != 2 {
+				elem = elemISOYear
+			}
+		case 'w':
+			elem = elemISOWeek
+		}
+		if elem == elemLiteral || n > 5 {
+			return nil, fmt.Errorf("pattern %q: %q is not supported", pattern, tok)
+		}
+		chunks = append(chunks, layoutChunk{elem: elem, text: tok})
+		pad = 0
+	}
+	return chunks, nil
+}
+
+// momentTokens are the moment.js tokens we understand, longest first so
+// the first match wins.  elemLiteral marks a token we don't support.
+var momentTokens = []struct {
+	tok  string
+	elem layoutElem
+}{
+	{"YYYY", elemLongYear},
+	{"YY", elemYear},
+	{"MMMM", elemLongMonth},
+	{"MMM", elemMonth},
+	{"MM", elemZeroMonth},
+	{"M", elemNumMonth},
+	{"DDDD", elemZeroYearDay},
+	{"DDD", elemUnderYearDay},
+	{"DD", elemZeroDay},
+	{"D", elemDay},
+	{"dddd", elemLongWeekDay},
+	{"ddd", elemWeekDay},
+	{"dd", elemLiteral},
+	{"d", elemNumWeekDay},
+	{"E", elemISOWeekDay},
+	{"GGGG", elemISOYear},
+	{"WW", elemISOWeek},
+	{"W", elemISOWeek},
+	{"HH", elemHour},
+	{"H", elemHour},
+	{"hh", elemZeroHour12},
+	{"h", elemHour12},
+	{"mm", elemZeroMinute},
+	{"m", elemMinute},
+	{"ss", elemZeroSecond},
+	{"s", elemSecond},
+	{"A", elemPM},
+	{"a", elempm},
+	{"ZZ", elemNumTZ},
+	{"Z", elemNumColonTZ},
+	{"X", elemUnixSeconds},
+	{"x", elemUnixMillis},
+}
+
+// clampCount turns a pattern letter count into an index of a 5 element
+// table.
+func clampCount(n int) int {
+	if n > 5 {
+		return 4
+	}
+	return n - 1
+}
+
+// momentUnsupported are the moment.js token letters (locale weeks,
+// quarters, ordinals, localized formats ...) we don't handle.
+const momentUnsupported = "QwgkNzeLlod"
+
+func momentChunks(pattern string) ([]layoutChunk, error) {
+	var chunks []layoutChunk
+nextToken:
+	for i := 0; i < len(pattern); {
+		c := pattern[i]
+		if c == '[' {
+			j := strings.IndexByte(pattern[i:], ']')
+			if j < 0 {
+				return nil, fmt.Errorf("pattern %q has an unterminated [", pattern)
+			}
+			chunks = append(chunks, layoutChunk{elem: elemLiteral, text: pattern[i+1 : i+j]})
+			i += j + 1
+			continue
+		}
+		if c == 'S' {
+			n := 1
+			for i+n < len(pattern) && pattern[i+n] == 'S' {
+				n++
+			}
+			chunks = append(chunks, layoutChunk{elem: elemFracSecond0, digits: n})
+			i += n
+			continue
+		}
+		if !isPatternRune(rune(c)) {
+			chunks = append(chunks, layoutChunk{elem: elemLiteral, text: string(c)})
+			i++
+			continue
+		}
+		for _, mt := range momentTokens {
+			if strings.HasPrefix(pattern[i:], mt.tok) {
+				if mt.elem == elemLiteral {
+					break
+				}
+				chunks = append(chunks, layoutChunk{elem: mt.elem, text: mt.tok})
+				i += len(mt.tok)
+				continue nextToken
+			}
+		}
+		if strings.IndexByte(momentUnsupported, c) >= 0 {
+			j := i + 1
+			for j < len(pattern) && pattern[j] == c {
+				j++
+			}
+			return nil, fmt.Errorf("pattern %q: %q is not supported", pattern, pattern[i:j])
+		}
+		// moment passes any other letter through as is
+		chunks = append(chunks, layoutChunk{elem: elemLiteral, text: string(c)})
+		i++
+	}
+	return chunks, nil
+}
+
+// patternMatch holds the fields found by matchChunks, -1 for fields that
+// were not in the pattern.  spans[i] is the [start, end) of chunk i in
+// the matched string.
+type patternMatch struct {
+	year, month, day, yday    int
+	hour, min, sec, nsec      int
+	pm                        int
+	isoYear, isoWeek, weekday int
+	sundayWeek, mondayWeek    int
+	offset                    int
+	hasOffset                 bool
+	zone                      string
+	unix                      string
+	unixMillis                bool
+	spans                     [][2]int
+}
+
+// matchChunks matches value against chunks in order, go time.Parse
+// style, with no backtracking.
+func matchChunks(chunks []layoutChunk, value string) (*patternMatch, error) {
+	m := &patternMatch{
+		year: -1, month: -1, day: -1, yday: -1,
+		hour: -1, min: -1, sec: -1, nsec: -1, pm: -1,
+		isoYear: -1, isoWeek: -1, weekday: -1,
+		sundayWeek: -1, mondayWeek: -1,
+	}
+	pos := 0
+	for _, c := range chunks {
+		start := pos
+		rest := value[pos:]
+		var n int
+		var err error
+		switch c.elem {
+		case elemLiteral:
+			n, err = matchLiteral(rest, c.text)
+		case elemLongYear, elemISOYear:
+			var v int
+			if v, n, err = matchNum(rest, 4, 4); c.elem == elemLongYear {
+				m.year = v
+			} else {
+				m.isoYear = v
+			}
+		case elemYear:
+			m.year, n, err = matchNum(rest, 2, 2)
+			if err == nil {
+				// same pivot as the time package
+				if m.year >= 69 {
+					m.year += 1900
+				} else {
+					m.year += 2000
+				}
+			}
+		case elemNumMonth, elemZeroMonth:
+			m.month, n, err = matchNum(rest, 1, 2)
+		case elemMonth, elemLongMonth:
+			var idx int
+			idx, n, err = matchName(rest, months, c.elem == elemMonth)
+			m.month = idx + 1
+		case elemDay, elemZeroDay, elemUnderDay:
+			if c.elem == elemUnderDay && strings.HasPrefix(rest, " ") {
+				rest = rest[1:]
+				pos++
+			}
+			m.day, n, err = matchNum(rest, 1, 2)
+		case elemUnderYearDay, elemZeroYearDay:
+			m.yday, n, err = matchNum(rest, 1, 3)
+		case elemWeekDay, elemLongWeekDay:
+			var idx int
+			// days lists mon..sun, so this is iso numbering
+			idx, n, err = matchName(rest, days[7:], c.elem == elemWeekDay)
+			m.weekday = idx + 1
+		case elemISOWeekDay:
+			m.weekday, n, err = matchNum(rest, 1, 1)
+		case elemNumWeekDay:
+			m.weekday, n, err = matchNum(rest, 1, 1)
+			if m.weekday == 0 {
+				m.weekday = 7
+			}
+		case elemISOWeek:
+			m.isoWeek, n, err = matchNum(rest, 1, 2)
+		case elemSundayWeek:
+			m.sundayWeek, n, err = matchNum(rest, 1, 2)
+		case elemMondayWeek:
+			m.mondayWeek, n, err = matchNum(rest, 1, 2)
+		case elemHour, elemHour12, elemZeroHour12, elemUnderHour12:
+			if c.elem == elemUnderHour12 && strings.HasPrefix(rest, " ") {
+				rest = rest[1:]
+				pos++
+			}
+			m.hour, n, err = matchNum(rest, 1, 2)
+		case elemMinute, elemZeroMinute:
+			m.min, n, err = matchNum(rest, 1, 2)
+		case elemSecond, elemZeroSecond:
+			m.sec, n, err = matchNum(rest, 1, 2)
+		case elemFracSecond0, elemFracSecond9:
+			m.nsec, n, err = matchFrac(rest, c)
+		case elemPM, elempm:
+			if len(rest) >= 2 {
+				switch strings.ToLower(rest[:2]) {
+				case "am":
+					m.pm, n = 0, 2
+				case "pm":
+					m.pm, n = 1, 2
+				}
+			}
+		case elemTZ:
+			for n < len(rest) && n < 5 && 'A' <= rest[n] && rest[n] <= 'Z' {
+				n++
+			}
+			if n < 3 {
+				n = 0
+			}
+			m.zone = rest[:n]
+		case elemUnixSeconds, elemUnixMillis:
+			if strings.HasPrefix(rest, "-") {
+				n++
+			}
+			for n < len(rest) && '0' <= rest[n] && rest[n] <= '9' {
+				n++
+			}
+			m.unix = rest[:n]
+			m.unixMillis = c.elem == elemUnixMillis
+		default:
+			// numeric offsets
+			m.offset, n, err = matchOffset(rest, c.elem)
+			m.hasOffset = n > 0
+		}
+		if err == nil && n == 0 && c.elem != elemLiteral && c.elem != elemFracSecond9 {
+			err = fmt.Errorf("cannot parse %q as %q", rest, c.text)
+		}
+		if err != nil {
+			return nil, err
+		}
+		pos += n
+		m.spans = append(m.spans, [2]int{start, pos})
+	}
+	if pos != len(value) {
+		return nil, fmt.Errorf("extra text: %q", value[pos:])
+	}
+	return m, nil
+}
+
+func matchLiteral(value, lit string) (int, error) {
+	n := 0
+	for len(lit) > 0 {
+		if lit[0] == ' ' {
+			// like the time package, spaces match any run of spaces
+			if n >= len(value) || value[n] != ' ' {
+				return 0, fmt.Errorf("cannot parse %q as %q", value, lit)
+			}
+			for n < len(value) && value[n] == ' ' {
+				n++
+			}
+			lit = strings.TrimLeft(lit, " ")
+			continue
+		}
+		if n >= len(value) || value[n] != lit[0] {
+			return 0, fmt.Errorf("cannot parse %q as %q", value[n:], lit)
+		}
+		n++
+		lit = lit[1:]
+	}
+	return n, nil
+}
+
+func matchNum(value string, minDigits, maxDigits int) (int, int, error) {
+	n := 0
+	for n < len(value) && n < maxDigits && '0' <= value[n] && value[n] <= '9' {
+		n++
+	}
+	if n < minDigits {
+		return 0, 0, fmt.Errorf("cannot parse %q as a %d digit number", value, minDigits)
+	}
+	v, _ := strconv.Atoi(value[:n])
+	return v, n, nil
+}
+
+// matchName finds the (case insensitive) name at the start of value,
+// returning its index.  abbrev matches the 3 letter abbreviations.
+func matchName(value string, names []string, abbrev bool) (int, int, error) {
+	lower := strings.ToLower(value)
+	for i, name := range names {
+		if abbrev {
+			name = name[:3]
+		}
+		if strings.HasPrefix(lower, name) {
+			return i, len(name), nil
+		}
+	}
+	return 0, 0, fmt.Errorf("cannot parse %q as a name", value)
+}
+
+func matchFrac(value string, c layoutChunk) (int, int, error) {
+	n := 0
+	if c.text != "" {
+		if len(value) == 0 || (value[0] != '.' && value[0] != ',') {
+			if c.elem == elemFracSecond9 {
+				// optional
+				return 0, 0, nil
+			}
+			return 0, 0, fmt.Errorf("cannot parse %q as %q", value, c.text)
+		}
+		n++
+	}
+	start := n
+	for n < len(value) && '0' <= value[n] && value[n] <= '9' && (c.elem == elemFracSecond9 || n-start < c.digits) {
+		n++
+	}
+	digits := n - start
+	if digits == 0 || (c.elem == elemFracSecond0 && digits != c.digits) {
+		return 0, 0, fmt.Errorf("cannot parse %q as fractional seconds", value)
+	}
+	if digits > 9 {
+		digits = 9
+	}
+	ns, _ := strconv.Atoi(value[start : start+digits])
+	for i := digits; i < 9; i++ {
+		ns *= 10
+	}
+	return ns, n, nil
+}
+
+// matchOffset parses a numeric zone offset in the form of the given
+// element, returning the offset in seconds east of UTC.
+func matchOffset(value string, elem layoutElem) (int, int, error) {
+	iso := elem >= elemISO8601TZ && elem <= elemISO8601ColonSecondsTZ
+	if iso && strings.HasPrefix(value, "Z") {
+		return 0, 1, nil
+	}
+	if len(value) == 0 || (value[0] != '+' && value[0] != '-') {
+		return 0, 0, fmt.Errorf("cannot parse %q as a zone offset", value)
+	}
+	colon := false
+	parts := 3
+	switch elem {
+	case elemISO8601ShortTZ, elemNumShortTZ:
+		parts = 1
+	case elemISO8601TZ, elemNumTZ:
+		parts = 2
+	case elemISO8601ColonTZ, elemNumColonTZ:
+		parts, colon = 2, true
+	case elemISO8601ColonSecondsTZ, elemNumColonSecondsTZ:
+		colon = true
+	}
+	n := 1
+	secs := 0
+	for i, mult := range []int{3600, 60, 1}[:parts] {
+		if i > 0 && colon {
+			if n >= len(value) || value[n] != ':' {
+				return 0, 0, fmt.Errorf("cannot parse %q as a zone offset", value)
+			}
+			n++
+		}
+		v, l, err := matchNum(value[n:], 2, 2)
+		if err != nil {
+			return 0, 0, fmt.Errorf("cannot parse %q as a zone offset", value)
+		}
+		secs += v * mult
+		n += l
+	}
+	if value[0] == '-' {
+		secs = -secs
+	}
+	return secs, n, nil
+}
+
+func orDefault(v, def int) int {
+	if v < 0 {
+		return def
+	}
+	return v
+}
+
+// time builds the time from the matched fields.
+func (m *patternMatch) time(loc *time.Location) (time.Time, error) {
+	if m.unix != "" {
+		v, err := strconv.ParseInt(m.unix, 10, 64)
+		if err != nil {
+			return time.Time{}, err
+		}
+		t := time.Unix(v, 0)
+		if m.unixMillis {
+			t = time.Unix(0, v*int64(time.Millisecond))
+		}
+		if loc != nil {
+			t = t.In(loc)
+		}
+		return t, nil
+	}
+
+	year := orDefault(m.year, 0)
+	month := orDefault(m.month, 1)
+	day := orDefault(m.day, 1)
+	switch {
+	case m.isoWeek >= 0:
+		year = orDefault(m.isoYear, year)
+		if m.isoWeek < 1 || m.isoWeek > 53 {
+			return time.Time{}, fmt.Errorf("week out of range")
+		}
+		// week 1 is the week with January 4th in it
+		jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, time.UTC)
+		monday := jan4.AddDate(0, 0, -((int(jan4.Weekday()) + 6) % 7))
+		d := monday.AddDate(0, 0, (m.isoWeek-1)*7+orDefault(m.weekday, 1)-1)
+		year, month, day = d.Year(), int(d.Month()), d.Day()
+	case m.sundayWeek >= 0 || m.mondayWeek >= 0:
+		// strftime %U and %W, the days before the first Sunday/Monday are
+		// week 0
+		jan1 := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+		week, first, weekday := m.sundayWeek, time.Sunday, orDefault(m.weekday, 7)%7
+		if week < 0 {
+			week, first, weekday = m.mondayWeek, time.Monday, (orDefault(m.weekday, 1)+6)%7
+		}
+		firstDay := (7 + int(first) - int(jan1.Weekday())) % 7
+		d := jan1.AddDate(0, 0, firstDay+(week-1)*7+weekday)
+		if d.Year() != year {
+			return time.Time{}, fmt.Errorf("week out of range")
+		}
+		year, month, day = d.Year(), int(d.Month()), d.Day()
+	case m.yday >= 0:
+		d := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, m.yday-1)
+		if m.yday < 1 || d.Year() != year {
+			return time.Time{}, fmt.Errorf("day-of-year out of range")
+		}
+		if (m.month >= 0 && int(d.Month()) != m.month) || (m.day >= 0 && d.Day() != m.day) {
+			return time.Time{}, fmt.Errorf("day-of-year does not match month and day")
+		}
+		month, day = int(d.Month()), d.Day()
+	}
+
+	hour := orDefault(m.hour, 0)
+	if m.pm >= 0 {
+		if hour < 1 || hour > 12 {
+			return time.Time{}, fmt.Errorf("hour out of range")
+		}
+		if m.pm == 1 && hour < 12 {
+			hour += 12
+		} else if m.pm == 0 && hour == 12 {
+			hour = 0
+		}
+	}
+	switch {
+	case month < 1 || month > 12:
+		return time.Time{}, fmt.Errorf("month out of range")
+	case day < 1 || day > daysIn(time.Month(month), year):
+		return time.Time{}, fmt.Errorf("day out of range")
+	case hour > 23:
+		return time.Time{}, fmt.Errorf("hour out of range")
+	case orDefault(m.min, 0) > 59:
+		return time.Time{}, fmt.Errorf("minute out of range")
+	case orDefault(m.sec, 0) > 59:
+		return time.Time{}, fmt.Errorf("second out of range")
+	}
+	date := func(l *time.Location) time.Time {
+		return time.Date(year, time.Month(month), day, hour, orDefault(m.min, 0), orDefault(m.sec, 0), orDefault(m.nsec, 0), l)
+	}
+
+	local := loc
+	if local == nil {
+		local = time.Local
+	}
+	switch {
+	case m.hasOffset:
+		t := date(time.FixedZone("", m.offset))
+		// like the time package, use the location if it agrees on the offset
+		if _, offset := t.In(local).Zone(); offset == m.offset {
+			return t.In(local), nil
+		}
+		return t, nil
+	case m.zone == "UTC" || m.zone == "GMT":
+		return date(time.UTC), nil
+	case m.zone != "":
+		t := date(local)
+		if name, _ := t.Zone(); name == m.zone {
+			return t, nil
+		}
+		// unknown abbreviation, a fabricated location with a zero offset
+		return date(time.FixedZone(m.zone, 0)), nil
+	case loc != nil:
+		return date(loc), nil
+	}
+	return date(time.UTC), nil
+}
+
+func daysIn(m time.Month, year int) int {
+	return time.Date(year, m+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
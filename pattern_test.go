@@ -0,0 +1,118 @@
+package dateparse
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type patternTest struct {
+	in, pattern string
+	dialect     Dialect
+	out, layout string
+	err         bool
+}
+
+var testPatterns = []patternTest{
+	// strftime
+	{in: "31/03/2014 10:11", pattern: "%d/%m/%Y %H:%M", dialect: DialectStrftime, out: "2014-03-31 10:11:00 +0000 UTC", layout: "02/01/2006 15:04"},
+	{in: "2014-03-31T10:11:59", pattern: "%FT%T", dialect: DialectStrftime, out: "2014-03-31 10:11:59 +0000 UTC", layout: "2006-01-02T15:04:05"},
+	{in: "Mon Mar  3 10:11:59 2014", pattern: "%a %b %e %H:%M:%S %Y", dialect: DialectStrftime, out: "2014-03-03 10:11:59 +0000 UTC", layout: "Mon Jan _2 15:04:05 2006"},
+	{in: "Mon Mar  3 2014", pattern: "%a %b %_d %Y", dialect: DialectStrftime, out: "2014-03-03 00:00:00 +0000 UTC", layout: "Mon Jan _2 2006"},
+	{in: "2014-03-01T 5:07 PM", pattern: "%Y-%m-%dT%l:%M %p", dialect: DialectStrftime, out: "2014-03-01 17:07:00 +0000 UTC"},
+	{in: "2014-03-01T11:07 PM", pattern: "%Y-%m-%dT%l:%M %p", dialect: DialectStrftime, out: "2014-03-01 23:07:00 +0000 UTC"},
+	{in: "2014-03-01T 5:07 PM", pattern: "%Y-%m-%dT%_I:%M %p", dialect: DialectStrftime, out: "2014-03-01 17:07:00 +0000 UTC"},
+	{in: "2014-03-01T5:07 PM", pattern: "%Y-%m-%dT%-l:%M %p", dialect: DialectStrftime, out: "2014-03-01 17:07:00 +0000 UTC", layout: "2006-01-02T3:04 PM"},
+	{in: "3/1/14 5:07:09 PM", pattern: "%-m/%-d/%y %-I:%M:%S %p", dialect: DialectStrftime, out: "2014-03-01 17:07:09 +0000 UTC", layout: "1/2/06 3:04:05 PM"},
+	{in: "2014-03-31 10:11:59.123456 +0200", pattern: "%Y-%m-%d %H:%M:%S.%f %z", dialect: DialectStrftime, out: "2014-03-31 08:11:59.123456 +0000 UTC", layout: "2006-01-02 15:04:05.000000 -0700"},
+	{in: "2014-090", pattern: "%Y-%j", dialect: DialectStrftime, out: "2014-03-31 00:00:00 +0000 UTC"},
+	{in: "2014-W14-1", pattern: "%G-W%V-%u", dialect: DialectStrftime, out: "2014-03-31 00:00:00 +0000 UTC"},
+	{in: "2021-W01-1", pattern: "%G-W%V-%u", dialect: DialectStrftime, out: "2021-01-04 00:00:00 +0000 UTC"},
+	{in: "2014 13 1", pattern: "%Y %U %w", dialect: DialectStrftime, out: "2014-03-31 00:00:00 +0000 UTC"},
+	{in: "2014 13 Mon", pattern: "%Y %W %a", dialect: DialectStrftime, out: "2014-03-31 00:00:00 +0000 UTC"},
+	{in: "1332151919", pattern: "%s", dialect: DialectStrftime, out: "2012-03-19 10:11:59 +0000 UTC"},
+	{in: "100% 2014", pattern: "100%% %Y", dialect: DialectStrftime, out: "2014-01-01 00:00:00 +0000 UTC"},
+	{in: "2014-13-31", pattern: "%Y-%m-%d", dialect: DialectStrftime, err: true},
+	{in: "2014-366", pattern: "%Y-%j", dialect: DialectStrftime, err: true},
+	{in: "2014", pattern: "%Q", dialect: DialectStrftime, err: true},
+	{in: "2014", pattern: "%Y%", dialect: DialectStrftime, err: true},
+	// java / icu
+	{in: "2014-03-31T10:11:59.123+02:00", pattern: "yyyy-MM-dd'T'HH:mm:ss.SSSXXX", dialect: DialectJava, out: "2014-03-31 08:11:59.123 +0000 UTC", layout: "2006-01-02T15:04:05.000Z07:00"},
+	{in: "2014-03-31T10:11:59.123Z", pattern: "yyyy-MM-dd'T'HH:mm:ss.SSSXXX", dialect: DialectJava, out: "2014-03-31 10:11:59.123 +0000 UTC", layout: "2006-01-02T15:04:05.000Z07:00"},
+	{in: "Mar 31, 2014 10:11 AM", pattern: "MMM d, yyyy hh:mm a", dialect: DialectICU, out: "2014-03-31 10:11:00 +0000 UTC", layout: "Jan 2, 2006 03:04 PM"},
+	{in: "Monday, March 31, 2014", pattern: "EEEE, MMMM d, yyyy", dialect: DialectJava, out: "2014-03-31 00:00:00 +0000 UTC", layout: "Monday, January 2, 2006"},
+	{in: "20140331101159", pattern: "yyyyMMddHHmmss", dialect: DialectJava, out: "2014-03-31 10:11:59 +0000 UTC", layout: "20060102150405"},
+	{in: "2014.090", pattern: "yyyy.DDD", dialect: DialectJava, out: "2014-03-31 00:00:00 +0000 UTC"},
+	{in: "2014-W14-1", pattern: "YYYY-'W'ww-e", dialect: DialectICU, out: "2014-03-31 00:00:00 +0000 UTC"},
+	// local weekdays are numbered from Monday, 7 is Sunday
+	{in: "2014-W14-7", pattern: "YYYY-'W'ww-e", dialect: DialectJava, out: "2014-04-06 00:00:00 +0000 UTC"},
+	{in: "2014-W14-7", pattern: "YYYY-'W'ww-c", dialect: DialectICU, out: "2014-04-06 00:00:00 +0000 UTC"},
+	// quoted literals that a go layout would read as elements
+	{in: "Jan 2014 day 31 month 03", pattern: "'Jan' yyyy 'day' dd 'month' MM", dialect: DialectJava, out: "2014-03-31 00:00:00 +0000 UTC"},
+	{in: "o'clock 10 2014-03-31", pattern: "'o''clock' HH yyyy-MM-dd", dialect: DialectJava, out: "2014-03-31 10:00:00 +0000 UTC", layout: "o'clock 15 2006-01-02"},
+	{in: "2014-03-31", pattern: "yyyy-MM-dd[ HH:mm]", dialect: DialectJava, err: true},
+	{in: "2014-03-31", pattern: "yyyy-MM-dd'T", dialect: DialectJava, err: true},
+	{in: "2014-03-31", pattern: "GGGG-MM-dd", dialect: DialectJava, err: true},
+	// moment
+	{in: "2014-03-31 10:11:59", pattern: "YYYY-MM-DD HH:mm:ss", dialect: DialectMoment, out: "2014-03-31 10:11:59 +0000 UTC", layout: "2006-01-02 15:04:05"},
+	{in: "31 March 2014 at 10:11", pattern: "D MMMM YYYY [at] HH:mm", dialect: DialectMoment, out: "2014-03-31 10:11:00 +0000 UTC", layout: "2 January 2006 at 15:04"},
+	{in: "2014-03-31T10:11:59+02:00", pattern: "YYYY-MM-DDTHH:mm:ssZ", dialect: DialectMoment, out: "2014-03-31 08:11:59 +0000 UTC", layout: "2006-01-02T15:04:05-07:00"},
+	{in: "2014-03-31 (Mo)", pattern: "YYYY-MM-DD (dd)", dialect: DialectMoment, err: true},
+	{in: "1384216367111", pattern: "x", dialect: DialectMoment, out: "2013-11-12 00:32:47.111 +0000 UTC"},
+	{in: "2014-03-31", pattern: "YYYY-MM-Do", dialect: DialectMoment, err: true},
+	// go layouts go straight to the time package
+	{in: "2014-03-31 10:11:59", pattern: "2006-01-02 15:04:05", dialect: DialectGo, out: "2014-03-31 10:11:59 +0000 UTC", layout: "2006-01-02 15:04:05"},
+}
+
+func TestParseWithPattern(t *testing.T) {
+	time.Local = time.UTC
+	for _, th := range testPatterns {
+		ts, err := ParseWithPattern(th.in, th.pattern, th.dialect)
+		if th.err {
+			assert.NotEqual(t, nil, err, "%q as %q got %v", th.in, th.pattern, ts)
+			continue
+		}
+		assert.Equal(t, nil, err, "%q as %q", th.in, th.pattern)
+		assert.Equal(t, th.out, fmt.Sprintf("%v", ts.In(time.UTC)), "%q as %q", th.in, th.pattern)
+
+		layout, err := ConvertPattern(th.pattern, th.dialect)
+		if th.layout == "" {
+			assert.NotEqual(t, nil, err, "%q should not have a go layout, got %q", th.pattern, layout)
+		} else {
+			assert.Equal(t, nil, err, "%q", th.pattern)
+			assert.Equal(t, th.layout, layout, "%q", th.pattern)
+		}
+	}
+}
+
+func TestParseWithPatternIn(t *testing.T) {
+	denverLoc, err := time.LoadLocation("America/Denver")
+	assert.Equal(t, nil, err)
+
+	// go layout path
+	ts, err := ParseWithPatternIn("2013-02-01 00:00", "%Y-%m-%d %H:%M", DialectStrftime, denverLoc)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2013-02-01 07:00:00 +0000 UTC", fmt.Sprintf("%v", ts.In(time.UTC)))
+
+	// matcher path
+	ts, err = ParseWithPatternIn("2013-032 00:00 MST", "%Y-%j %H:%M %Z", DialectStrftime, denverLoc)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2013-02-01 07:00:00 +0000 UTC", fmt.Sprintf("%v", ts.In(time.UTC)))
+	zone, _ := ts.Zone()
+	assert.Equal(t, "MST", zone)
+
+	ts, err = ParseWithPatternIn("2013-032", "%Y-%j", DialectStrftime, denverLoc)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2013-02-01 07:00:00 +0000 UTC", fmt.Sprintf("%v", ts.In(time.UTC)))
+
+	// patterns round trip through ConvertLayout
+	for _, d := range []Dialect{DialectStrftime, DialectJava, DialectICU, DialectMoment} {
+		pattern, err := ConvertLayout("2006-01-02 15:04:05.000000", d)
+		assert.Equal(t, nil, err)
+		ts, err = ParseWithPattern("2014-03-31 10:11:59.123456", pattern, d)
+		assert.Equal(t, nil, err, "%v %q", d, pattern)
+		assert.Equal(t, "2014-03-31 10:11:59.123456 +0000 UTC", fmt.Sprintf("%v", ts.In(time.UTC)), "%v %q", d, pattern)
+	}
+}
@@ -0,0 +1,202 @@
+package dateparse
+
+import (
+	"strconv"
+	"strings"
+)
+
+// spokenOrdinals are the spelled day numbers, "twenty" and "thirty"
+// combine with the first nine, ie "twenty-first"
+var spokenOrdinals = map[string]int{
+	"first": 1, "second": 2, "third": 3, "fourth": 4, "fifth": 5,
+	"sixth": 6, "seventh": 7, "eighth": 8, "ninth": 9, "tenth": 10,
+	"eleventh": 11, "twelfth": 12, "thirteenth": 13, "fourteenth": 14,
+	"fifteenth": 15, "sixteenth": 16, "seventeenth": 17, "eighteenth": 18,
+	"nineteenth": 19, "twentieth": 20, "thirtieth": 30,
+}
+
+var spokenTens = map[string]int{
+	"twenty": 20,
+	"thirty": 30,
+}
+
+// spokenTimes are the time words and the clock they stand for
+var spokenTimes = map[string]string{
+	"noon":     "12:00 PM",
+	"midday":   "12:00 PM",
+	"midnight": "12:00 AM",
+}
+
+// spokenWords are the filler words of a spoken date
+var spokenWords = map[string]bool{"the": true, "of": true}
+
+// spokenHints reads the whole words of datestr for any a spoken date has:
+// filler words, spelled ordinals, ordinal suffixes after a number ("3rd")
+// or time words.  clock is true if it has a time word, ie "noon".
+func spokenHints(datestr string) (spoken, clock bool) {
+	var buf [16]byte
+	for i := 0; i < len(datestr); {
+		if !isASCIILetter(datestr[i]) {
+			i++
+			continue
+		}
+		j := i
+		for j < len(datestr) && isASCIILetter(datestr[j]) {
+			j++
+		}
+		if j-i <= len(buf) {
+			w := buf[:j-i]
+			for k := range w {
+				w[k] = datestr[i+k] | 0x20
+			}
+			switch {
+			case spokenTimes[string(w)] != "":
+				return true, true
+			case spokenWords[string(w)] || spokenOrdinals[string(w)] > 0 || spokenTens[string(w)] > 0:
+				spoken = true
+			case i > 0 && datestr[i-1] >= '0' && datestr[i-1] <= '9':
+				// the suffix of an ordinal, see letterToken
+				lower := string(w)
+				spoken = spoken || lower == "st" || lower == "nd" || lower == "rd" || lower == "th"
+			}
+		}
+		i = j
+	}
+	return spoken, false
+}
+
+func isASCIILetter(c byte) bool {
+	return c|0x20 >= 'a' && c|0x20 <= 'z'
+}
+
+// spokenDate rewrites a spoken english date as "January 2, 2006 3:04 PM",
+// which the state machine reads: "the 3rd of March, 2020 at noon",
+// "March the third", "twenty-first of June", "3rd March 2020 10:30".
+// Filler words and weekdays are dropped, ordinals become numbers and
+// noon/midnight become clocks.  Dates without any of those, or with
+// separators other than spaces, commas, dashes and dots, are not spoken.
+func spokenDate(datestr string) (string, bool) {
+	toks := lex(datestr, 0, len(datestr), nil)
+	var month, day, year string
+	var clock []string
+	marked := false
+	word := func(i int) string {
+		if i < len(toks) && (toks[i].Kind == TokenWord || toks[i].Kind == TokenZone) {
+			return strings.ToLower(toks[i].Text)
+		}
+		return ""
+	}
+	// next is the index of the token after toks[i] and its separator
+	next := func(i int) int {
+		if i+1 < len(toks) && toks[i+1].Kind == TokenSeparator {
+			return i + 2
+		}
+		return i + 1
+	}
+	for i := 0; i < len(toks); i++ {
+		tok := toks[i]
+		w := word(i)
+		switch {
+		case tok.Kind == TokenSeparator:
+			if strings.Trim(tok.Text, " ,-.") != "" {
+				return "", false
+			}
+		case tok.Kind == TokenWeekday:
+		case tok.Kind == TokenOrdinal:
+			marked = true
+		case w == "the" || w == "of" || w == "on" || w == "at":
+			marked = marked || w != "at"
+		case tok.Kind == TokenMonth:
+			if month != "" {
+				return "", false
+			}
+			month = tok.Text
+		case spokenTimes[w] != "":
+			// with any zone after it, ie "noon MST"
+			start := i
+			for i+1 < len(toks) && isClockToken(toks, i+1) {
+				i++
+			}
+			clock = append(clock, spokenTimes[w]+datestr[toks[start].End:toks[i].End])
+			marked = true
+		case spokenOrdinals[w] > 0 && day == "":
+			day = strconv.Itoa(spokenOrdinals[w])
+			marked = true
+		case spokenTens[w] > 0 && day == "":
+			marked = true
+			n := spokenTens[w]
+			if j := next(i); spokenOrdinals[word(j)] > 0 && spokenOrdinals[word(j)] < 10 {
+				n += spokenOrdinals[word(j)]
+				i = j
+			}
+			day = strconv.Itoa(n)
+		case tok.Kind == TokenNumber && isClockAt(toks, i):
+			// the clock runs to the next date token, ie "10:30 pm on"
+			start := i
+			for i+1 < len(toks) && isClockToken(toks, i+1) {
+				i++
+			}
+			text := strings.TrimSpace(datestr[toks[start].Start:toks[i].End])
+			if !strings.Contains(text, ":") {
+				// 9am, the state machine wants 9:00 am
+				text = toks[start].Text + ":00 " + strings.TrimSpace(datestr[toks[start].End:toks[i].End])
+			}
+			// the state machine only reads 12 am as midnight in capitals
+			clock = append(clock, strings.ToUpper(text))
+		case tok.Kind == TokenNumber && i+1 < len(toks) && toks[i+1].Kind == TokenOrdinal && day == "":
+			day = tok.Text
+		case tok.Kind == TokenNumber && tok.Digits == 4 && year == "":
+			year = tok.Text
+		case tok.Kind == TokenNumber && tok.Digits <= 2 && day == "":
+			day = tok.Text
+		default:
+			return "", false
+		}
+	}
+	if !marked || month == "" || day == "" || len(clock) > 1 {
+		return "", false
+	}
+	spoken := month + " " + day
+	if year != "" {
+		spoken += ", " + year
+	}
+	if len(clock) > 0 {
+		spoken += " " + clock[0]
+	}
+	return spoken, true
+}
+
+// isClockAt is true if the number toks[i] starts a time, ie "10:30" or
+// "10 pm"
+func isClockAt(toks []Token, i int) bool {
+	if i+1 >= len(toks) {
+		return false
+	}
+	switch n := toks[i+1]; {
+	case n.Kind == TokenSeparator && strings.HasPrefix(n.Text, ":"):
+		return true
+	case n.Kind == TokenAMPM:
+		return true
+	case n.Kind == TokenSeparator && n.Text == " " && i+2 < len(toks) && toks[i+2].Kind == TokenAMPM:
+		return true
+	}
+	return false
+}
+
+// isClockToken is true if toks[i] continues the time before it
+func isClockToken(toks []Token, i int) bool {
+	switch toks[i].Kind {
+	case TokenAMPM, TokenZone, TokenOffset:
+		return true
+	case TokenNumber:
+		prev := toks[i-1].Text
+		return prev == ":" || prev == "."
+	case TokenSeparator:
+		if toks[i].Text == ":" || toks[i].Text == "." {
+			return true
+		}
+		// a space only within the time, ie "10:30 pm"
+		return toks[i].Text == " " && i+1 < len(toks) && isClockToken(toks, i+1) && toks[i+1].Kind != TokenNumber
+	}
+	return false
+}
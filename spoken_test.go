@@ -0,0 +1,99 @@
+package dateparse
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpoken(t *testing.T) {
+	tests := []struct {
+		in  string
+		out string
+	}{
+		{"the 3rd of March, 2020 at noon", "2020-03-03 12:00:00"},
+		{"the 3rd of March 2020", "2020-03-03 00:00:00"},
+		{"3rd March 2020", "2020-03-03 00:00:00"},
+		{"3rd of March 2020 at 9am", "2020-03-03 09:00:00"},
+		{"3rd of March 2020 at 9 pm", "2020-03-03 21:00:00"},
+		{"3rd March 2020 10:30:15.123 UTC", "2020-03-03 10:30:15.123"},
+		{"Tuesday, the 3rd of March 2020 at 10:30 pm", "2020-03-03 22:30:00"},
+		{"noon on the 3rd of March 2020", "2020-03-03 12:00:00"},
+		{"the twenty-first of June 2021 at midnight", "2021-06-21 00:00:00"},
+		{"twenty first of June 2021 at 12:30 am", "2021-06-21 00:30:00"},
+		{"the thirtieth of April, 2020", "2020-04-30 00:00:00"},
+		{"the first of May 2020 at midday", "2020-05-01 12:00:00"},
+		{"Jan 2, 2020 at noon", "2020-01-02 12:00:00"},
+		{"2 January 2020 noon", "2020-01-02 12:00:00"},
+		// no year
+		{"March the 3rd", "0000-03-03 00:00:00"},
+		{"twenty-first of June", "0000-06-21 00:00:00"},
+		// already read by the state machine, unchanged
+		{"March 3rd 2020", "2020-03-03 00:00:00"},
+		{"September 17th, 2012", "2012-09-17 00:00:00"},
+	}
+	for _, tc := range tests {
+		ts, err := ParseAny(tc.in)
+		assert.Equal(t, nil, err, tc.in)
+		assert.Equal(t, tc.out, ts.Format("2006-01-02 15:04:05.999999999"), tc.in)
+	}
+
+	for _, in := range []string{
+		"the 3rd",
+		"bogus of nothing",
+		"the 3rd of March and the 4th of April",
+		"[the 3rd of March 2020]",
+		"the 32nd of March 2020",
+	} {
+		_, err := ParseAny(in)
+		assert.NotEqual(t, nil, err, in)
+	}
+	_, err := ParseAny("[the 3rd of March 2020]", Lenient(true))
+	assert.Equal(t, nil, err)
+
+	// the rewrite is a restart, the layout says so
+	l, err := ParseLayout("the 3rd of March 2020 at noon")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, l.Flags&LayoutRewritten != 0)
+	e, err := Explain("3rd March 2020")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "March 3, 2020", e.Parsed)
+
+	// a date the state machine reads is not parsed twice, the parse
+	// checking it needs no rewrite is re-used
+	p, err := parseTime("September 17th, 2012", nil)
+	assert.Equal(t, nil, err)
+	assert.True(t, p.parsed != nil)
+	ts, err := p.parse()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2012-09-17", ts.Format("2006-01-02"))
+
+	denver, _ := time.LoadLocation("America/Denver")
+	ts, err = ParseIn("the 3rd of March 2020 at noon MST", denver)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2020-03-03T12:00:00-07:00", ts.Format(time.RFC3339))
+}
+
+func TestSpokenHints(t *testing.T) {
+	tests := []struct {
+		in            string
+		spoken, clock bool
+	}{
+		{"the 3rd of March", true, false},
+		{"3RD March 2020", true, false},
+		{"twenty-first of June", true, false},
+		{"Jan 2, 2020 at Noon", true, true},
+		// whole words only, not "th" in "Thu" or "st" in "PST"
+		{"Thu, 02 Jan 2006 15:04:05 PST", false, false},
+		{"Monday, August 2 2006 EST", false, false},
+		{"Mon Jan 2 15:04:05 MST 2006", false, false},
+		{"2006-01-02T15:04:05Z", false, false},
+		{"seconds", false, false},
+	}
+	for _, tc := range tests {
+		spoken, clock := spokenHints(tc.in)
+		assert.Equal(t, tc.spoken, spoken, tc.in)
+		assert.Equal(t, tc.clock, clock, tc.in)
+	}
+}
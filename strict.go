@@ -0,0 +1,173 @@
+package dateparse
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Strictness are the guesses a parse should fail on rather than make, see
+// Strict.
+type Strictness uint16
+
+const (
+	// StrictAmbiguousMMDD rejects dates that read as mm/dd or dd/mm (or
+	// dd-mon-yy vs yy-mon-dd), as ParseStrict always has
+	StrictAmbiguousMMDD Strictness = 1 << iota
+	// StrictTwoDigitYear rejects two digit years, whose century is a guess
+	StrictTwoDigitYear
+	// StrictZoneAbbrev rejects zone abbreviations the location doesn't
+	// know (go reads them as UTC) or that have several common meanings,
+	// ie CST or IST
+	StrictZoneAbbrev
+	// StrictEpoch rejects bare numbers read as epoch seconds, millis etc
+	StrictEpoch
+	// StrictNoZone rejects dates without an offset or zone, whose instant
+	// depends on the location given
+	StrictNoZone
+	// StrictWeekday rejects weekday names that don't match the date
+	StrictWeekday
+	// StrictTrailingText rejects trailing text the parser would otherwise
+	// drop, ie "(GMT Daylight Time)"
+	StrictTrailingText
+)
+
+const (
+	// StrictDate rejects guesses about the date: month/day order, century
+	// and contradicting weekdays
+	StrictDate = StrictAmbiguousMMDD | StrictTwoDigitYear | StrictWeekday
+	// StrictZone rejects guesses about the instant: zone abbreviations
+	// and missing zones
+	StrictZone = StrictZoneAbbrev | StrictNoZone
+	// StrictAll fails rather than guess anything
+	StrictAll = StrictDate | StrictZone | StrictEpoch | StrictTrailingText
+)
+
+var strictnessNames = []string{
+	"ambiguous-mmdd",
+	"two-digit-year",
+	"zone-abbrev",
+	"epoch",
+	"no-zone",
+	"weekday",
+	"trailing-text",
+}
+
+var (
+	// ErrTwoDigitYear for dates such as 8/8/71 whose century is a guess,
+	// see StrictTwoDigitYear.
+	ErrTwoDigitYear = fmt.Errorf("This date has a two digit year")
+	// ErrZoneAbbrev see StrictZoneAbbrev.
+	ErrZoneAbbrev = fmt.Errorf("This date has an unknown or ambiguous zone abbreviation")
+	// ErrEpoch see StrictEpoch.
+	ErrEpoch = fmt.Errorf("This date is an epoch number")
+	// ErrNoZone see StrictNoZone.
+	ErrNoZone = fmt.Errorf("This date has no zone or offset")
+	// ErrWeekdayMismatch see StrictWeekday.
+	ErrWeekdayMismatch = fmt.Errorf("This date has a weekday that doesn't match it")
+	// ErrTrailingText see StrictTrailingText.
+	ErrTrailingText = fmt.Errorf("This date has trailing text")
+)
+
+// Strict is an option making any parse fail, with one of the Err
+// values, rather than make the given guesses.  It adds to any Strict
+// option before it, and ParseStrict always has StrictAmbiguousMMDD.
+//
+//	t, err := dateparse.ParseAny(record, dateparse.Strict(dateparse.StrictAll))
+func Strict(s Strictness) ParserOption {
+	return func(p *parser) error {
+		p.strict |= s
+		return nil
+	}
+}
+
+func (s Strictness) String() string {
+	if s == StrictAll {
+		return "all"
+	}
+	var names []string
+	for i, name := range strictnessNames {
+		if s&(1<<uint(i)) != 0 {
+			names = append(names, name)
+		}
+	}
+	return strings.Join(names, "|")
+}
+
+// MarshalText writes the strictness as its names, ie "epoch|no-zone"
+func (s Strictness) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText reads names separated by "|" or ",", as written by
+// MarshalText, and also "date", "zone" and "all".
+func (s *Strictness) UnmarshalText(text []byte) error {
+	var v Strictness
+	for _, name := range strings.FieldsFunc(string(text), func(r rune) bool { return r == '|' || r == ',' }) {
+		switch name = strings.TrimSpace(name); name {
+		case "date":
+			v |= StrictDate
+			continue
+		case "zone":
+			v |= StrictZone
+			continue
+		case "all":
+			v |= StrictAll
+			continue
+		}
+		found := false
+		for i, n := range strictnessNames {
+			if n == name {
+				v |= 1 << uint(i)
+				found = true
+			}
+		}
+		if !found {
+			return fmt.Errorf("unknown strictness %q", name)
+		}
+	}
+	*s = v
+	return nil
+}
+
+// checkStrict returns the error for the first guess made parsing t that
+// the parser's strictness rejects.
+func (p *parser) checkStrict(t time.Time) error {
+	if p.strict&StrictAmbiguousMMDD != 0 && p.ambiguousMD {
+		return ErrAmbiguousMMDD
+	}
+	if p.strict&StrictTrailingText != 0 && p.trailing != "" {
+		return ErrTrailingText
+	}
+	// (Z)ulu is only a literal in the layout, the parser knows if it read one
+	hasZone, hasAbbrev := p.zulu(), false
+	for _, c := range layoutChunks(string(p.format)) {
+		switch {
+		case c.elem == elemYear:
+			if p.strict&StrictTwoDigitYear != 0 {
+				return ErrTwoDigitYear
+			}
+		case c.elem == elemTZ:
+			hasZone, hasAbbrev = true, true
+		case c.elem > elemTZ && c.elem <= elemNumColonSecondsTZ:
+			hasZone = true
+		}
+	}
+	if p.strict&StrictNoZone != 0 && !hasZone {
+		return ErrNoZone
+	}
+	if p.strict&StrictZoneAbbrev != 0 && hasAbbrev {
+		name, _ := t.Zone()
+		if name != "UTC" && name != "GMT" && (isFabricatedZone(t, name) || len(zoneAbbrevs[name]) > 1) {
+			return ErrZoneAbbrev
+		}
+	}
+	if p.strict&StrictWeekday != 0 {
+		for _, tok := range lex(p.input, 0, len(p.input), nil) {
+			if tok.Kind == TokenWeekday && time.Weekday(tok.Value) != t.Weekday() {
+				return ErrWeekdayMismatch
+			}
+		}
+	}
+	return nil
+}
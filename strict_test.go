@@ -0,0 +1,81 @@
+package dateparse
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStrict(t *testing.T) {
+	denver, err := time.LoadLocation("America/Denver")
+	assert.Equal(t, nil, err)
+
+	tests := []struct {
+		in     string
+		strict Strictness
+		err    error
+	}{
+		{"8/8/71", StrictAmbiguousMMDD, ErrAmbiguousMMDD},
+		{"2006-01-02 15:04:05", StrictAmbiguousMMDD, nil},
+		{"Jan 2, 71", StrictTwoDigitYear, ErrTwoDigitYear},
+		{"Jan 2, 1971", StrictTwoDigitYear, nil},
+		{"1332151919", StrictEpoch, ErrEpoch},
+		{"1332151919", StrictDate, nil},
+		{"2006-01-02 15:04:05", StrictNoZone, ErrNoZone},
+		{"2006-01-02T15:04:05Z", StrictNoZone, nil},
+		{"2006-01-02T15:04:05.123Z", StrictNoZone, nil},
+		{"2009-08-12T22:15Z", StrictNoZone, nil},
+		// a Z the parser didn't read as (Z)ulu, these are local times
+		{"2006-01-02Z", StrictNoZone, ErrNoZone},
+		{"12 Feb 2006, 19:17 Z", StrictNoZone, ErrNoZone},
+		{"2006-01-02 15:04:05 -0700", StrictNoZone, nil},
+		{"2006-01-02 15:04:05 +0000 UTC", StrictAll, nil},
+		{"Mon Jan 2 15:04:05 CST 2006", StrictZoneAbbrev, ErrZoneAbbrev},
+		// unknown in UTC, go reads it as UTC
+		{"Mon Jan 2 15:04:05 EST 2006", StrictZoneAbbrev, ErrZoneAbbrev},
+		{"Mon Jan 2 15:04:05 UTC 2006", StrictZoneAbbrev, nil},
+		{"Mon Jan 2 15:04:05 CST 2006", StrictNoZone, nil},
+		{"Mon, 02 Jan 2006 15:04:05 -0700", StrictWeekday, nil},
+		{"Tue, 02 Jan 2006 15:04:05 -0700", StrictWeekday, ErrWeekdayMismatch},
+		{"Mon Jan 02 2006 15:04:05 GMT+0100 (GMT Daylight Time)", StrictTrailingText, ErrTrailingText},
+		{"Mon Jan 02 2006 15:04:05 GMT+0100 (GMT Daylight Time)", StrictDate, nil},
+		{"Tue, 3 Jan 2006 15:04:05 -0700", StrictAll, nil},
+	}
+	for _, tc := range tests {
+		_, err := ParseAny(tc.in, Strict(tc.strict))
+		assert.Equal(t, tc.err, err, "%q %s", tc.in, tc.strict)
+	}
+
+	// the location knows MST, and it has one meaning
+	_, err = ParseIn("Mon Jan 2 15:04:05 MST 2006", denver, Strict(StrictZoneAbbrev))
+	assert.Equal(t, nil, err)
+	_, err = ParseIn("Mon Jan 2 15:04:05 MST 2006", nil, Strict(StrictZoneAbbrev))
+	assert.Equal(t, ErrZoneAbbrev, err)
+
+	// options add up, and ParseStrict keeps rejecting mm/dd
+	_, err = ParseAny("2006-01-02", Strict(StrictEpoch), Strict(StrictNoZone))
+	assert.Equal(t, ErrNoZone, err)
+	_, err = ParseStrict("3/1/2014 10:00 +0100", Strict(StrictNoZone))
+	assert.Equal(t, ErrAmbiguousMMDD, err)
+
+	// batches skip the layout cache when strict
+	res := ParseMany([]string{"Mon, 02 Jan 2006 15:04:05 -0700", "Mon, 03 Jan 2006 15:04:05 -0700"}, Strict(StrictWeekday))
+	assert.Equal(t, nil, res.Errors[0])
+	assert.Equal(t, ErrWeekdayMismatch, res.Errors[1])
+	assert.Equal(t, 1, res.Failed)
+}
+
+func TestStrictnessText(t *testing.T) {
+	b, err := (StrictTwoDigitYear | StrictNoZone).MarshalText()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "two-digit-year|no-zone", string(b))
+	assert.Equal(t, "all", StrictAll.String())
+
+	var s Strictness
+	assert.Equal(t, nil, s.UnmarshalText([]byte("date, epoch")))
+	assert.Equal(t, StrictDate|StrictEpoch, s)
+	assert.Equal(t, nil, s.UnmarshalText([]byte("all")))
+	assert.Equal(t, StrictAll, s)
+	assert.NotEqual(t, nil, s.UnmarshalText([]byte("lenient")))
+}
@@ -0,0 +1,293 @@
+package dateparse
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Config controls how Time and NullTime read and write their values.
+type Config struct {
+	// Location to use for values without zone/offset info, same rules as
+	// ParseIn.  nil uses ParseAny rules.
+	Location *time.Location
+	// Options passed to the parser, ie PreferMonthFirst(false)
+	Options []ParserOption
+	// Format is the go layout values are written as.  Empty means the
+	// remembered layout if KeepLayout is set, otherwise Value hands the
+	// driver a time.Time and json/text use RFC3339.
+	Format string
+	// KeepLayout remembers the Layout each value was read in, so it is
+	// written back out in that same format.
+	KeepLayout bool
+}
+
+// DefaultConfig is used by Time and NullTime values that don't have a
+// Config of their own.
+var DefaultConfig = &Config{}
+
+func configOr(c *Config) *Config {
+	if c == nil {
+		return DefaultConfig
+	}
+	return c
+}
+
+// parse a string value per the config, the layout is only returned when
+// KeepLayout is set.
+func (c *Config) parse(datestr string) (time.Time, *Layout, error) {
+	p, err := parseTime(datestr, c.Location, c.Options...)
+	if err != nil {
+		return time.Time{}, nil, err
+	}
+	t, err := p.parse()
+	if err != nil || !c.KeepLayout {
+		return t, nil, err
+	}
+	l := p.layout(datestr)
+	return t, &l, nil
+}
+
+// scan converts a database value, valid is false for NULL.
+func (c *Config) scan(src interface{}) (t time.Time, l *Layout, valid bool, err error) {
+	switch v := src.(type) {
+	case nil:
+		return time.Time{}, nil, false, nil
+	case time.Time:
+		return v, nil, true, nil
+	case string:
+		t, l, err = c.parse(v)
+	case []byte:
+		t, l, err = c.parse(string(v))
+	case int64:
+		// epoch seconds, ms etc, by digit count same as a string
+		t, l, err = c.parse(strconv.FormatInt(v, 10))
+	default:
+		return time.Time{}, nil, false, fmt.Errorf("can not scan %T into a date", src)
+	}
+	if err != nil {
+		return time.Time{}, nil, false, err
+	}
+	return t, l, true, nil
+}
+
+// unmarshalJSON reads a json string or number, valid is false for null.
+func (c *Config) unmarshalJSON(data []byte) (t time.Time, l *Layout, valid bool, err error) {
+	data = bytes.TrimSpace(data)
+	switch {
+	case string(data) == "null":
+		return time.Time{}, nil, false, nil
+	case len(data) > 0 && data[0] == '"':
+		var s string
+		if err = json.Unmarshal(data, &s); err != nil {
+			return time.Time{}, nil, false, err
+		}
+		t, l, err = c.parse(s)
+	default:
+		// a number, ie epoch seconds or milliseconds
+		t, l, err = c.parse(string(data))
+	}
+	if err != nil {
+		return time.Time{}, nil, false, err
+	}
+	return t, l, true, nil
+}
+
+// layoutFor is the layout to write values in, nil for the default
+// formats.
+func (c *Config) layoutFor(l *Layout) *Layout {
+	if c.Format != "" {
+		return &Layout{Layout: c.Format}
+	}
+	return l
+}
+
+func (c *Config) value(t time.Time, l *Layout) driver.Value {
+	l = c.layoutFor(l)
+	switch {
+	case l == nil:
+		return t
+	case l.Kind != LayoutGo:
+		n, _ := strconv.ParseInt(l.Format(t), 10, 64)
+		return n
+	}
+	return l.Format(t)
+}
+
+func (c *Config) marshalJSON(t time.Time, l *Layout) ([]byte, error) {
+	l = c.layoutFor(l)
+	switch {
+	case l == nil:
+		return t.MarshalJSON()
+	case l.Kind != LayoutGo:
+		return []byte(l.Format(t)), nil
+	}
+	return json.Marshal(l.Format(t))
+}
+
+func (c *Config) marshalText(t time.Time, l *Layout) ([]byte, error) {
+	if l = c.layoutFor(l); l == nil {
+		return t.MarshalText()
+	}
+	return []byte(l.Format(t)), nil
+}
+
+// Time is a time.Time that can be read from any date format dateparse
+// understands: from legacy varchar/text database columns, json payloads
+// (strings, or numbers for epochs) and text (encoding.TextUnmarshaler,
+// which yaml and many config decoders use).
+//
+//	var created dateparse.Time
+//	err := db.QueryRow("SELECT created FROM users WHERE id = ?", 1).Scan(&created)
+//
+// Scan accepts string, []byte, int64 epochs and time.Time.  Use NullTime
+// for columns that may be NULL.  Set Config before decoding into a value
+// to override DefaultConfig for that field.
+type Time struct {
+	time.Time
+	// Config used to read and write the value, nil uses DefaultConfig
+	Config *Config
+	// Layout the value was read in, only kept if Config.KeepLayout
+	Layout *Layout
+}
+
+// Scan implements the sql.Scanner interface.
+func (t *Time) Scan(src interface{}) error {
+	v, l, valid, err := configOr(t.Config).scan(src)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		return fmt.Errorf("can not scan NULL into dateparse.Time, use dateparse.NullTime")
+	}
+	t.Time, t.Layout = v, l
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (t Time) Value() (driver.Value, error) {
+	return configOr(t.Config).value(t.Time, t.Layout), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, accepting a
+// json string in any format, or a number of epoch seconds, milliseconds
+// etc.  null is a no-op, as for time.Time.
+func (t *Time) UnmarshalJSON(data []byte) error {
+	v, l, valid, err := configOr(t.Config).unmarshalJSON(data)
+	if err != nil || !valid {
+		return err
+	}
+	t.Time, t.Layout = v, l
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (t Time) MarshalJSON() ([]byte, error) {
+	return configOr(t.Config).marshalJSON(t.Time, t.Layout)
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (t *Time) UnmarshalText(data []byte) error {
+	v, l, err := configOr(t.Config).parse(string(data))
+	if err != nil {
+		return err
+	}
+	t.Time, t.Layout = v, l
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (t Time) MarshalText() ([]byte, error) {
+	return configOr(t.Config).marshalText(t.Time, t.Layout)
+}
+
+// NullTime is a Time that may be NULL, like sql.NullTime.  Empty strings
+// are read as NULL as well, and it is written as json null when not Valid.
+type NullTime struct {
+	Time  time.Time
+	Valid bool // Valid is true if Time is not NULL
+	// Config used to read and write the value, nil uses DefaultConfig
+	Config *Config
+	// Layout the value was read in, only kept if Config.KeepLayout
+	Layout *Layout
+}
+
+func isBlank(data []byte) bool {
+	return len(bytes.TrimSpace(data)) == 0
+}
+
+// Scan implements the sql.Scanner interface.
+func (n *NullTime) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case string:
+		if isBlank([]byte(v)) {
+			src = nil
+		}
+	case []byte:
+		if isBlank(v) {
+			src = nil
+		}
+	}
+	t, l, valid, err := configOr(n.Config).scan(src)
+	if err != nil {
+		return err
+	}
+	n.Time, n.Layout, n.Valid = t, l, valid
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (n NullTime) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return configOr(n.Config).value(n.Time, n.Layout), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, null and ""
+// are not Valid.
+func (n *NullTime) UnmarshalJSON(data []byte) error {
+	if s := bytes.TrimSpace(data); len(s) == 2 && s[0] == '"' && s[1] == '"' {
+		data = []byte("null")
+	}
+	t, l, valid, err := configOr(n.Config).unmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	n.Time, n.Layout, n.Valid = t, l, valid
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (n NullTime) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return configOr(n.Config).marshalJSON(n.Time, n.Layout)
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface, empty
+// text is not Valid.
+func (n *NullTime) UnmarshalText(data []byte) error {
+	if isBlank(data) {
+		n.Time, n.Layout, n.Valid = time.Time{}, nil, false
+		return nil
+	}
+	t, l, err := configOr(n.Config).parse(string(data))
+	if err != nil {
+		return err
+	}
+	n.Time, n.Layout, n.Valid = t, l, true
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (n NullTime) MarshalText() ([]byte, error) {
+	if !n.Valid {
+		return []byte{}, nil
+	}
+	return configOr(n.Config).marshalText(n.Time, n.Layout)
+}
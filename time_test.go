@@ -0,0 +1,153 @@
+package dateparse
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// make sure we implement the interfaces
+var (
+	_ sql.Scanner   = (*Time)(nil)
+	_ driver.Valuer = Time{}
+	_ sql.Scanner   = (*NullTime)(nil)
+	_ driver.Valuer = NullTime{}
+)
+
+func TestTimeScan(t *testing.T) {
+	time.Local = time.UTC
+
+	for _, src := range []interface{}{
+		"2014-04-26 17:24:37",
+		[]byte("04/26/2014 17:24:37"),
+		"Sat, 26 Apr 2014 17:24:37 +0000",
+		int64(1398533077),
+		time.Date(2014, 4, 26, 17, 24, 37, 0, time.UTC),
+	} {
+		var ts Time
+		assert.Equal(t, nil, ts.Scan(src), "%v", src)
+		assert.Equal(t, "2014-04-26 17:24:37 +0000 UTC", fmt.Sprintf("%v", ts.In(time.UTC)), "%v", src)
+	}
+
+	var ts Time
+	assert.NotEqual(t, nil, ts.Scan(nil))
+	assert.NotEqual(t, nil, ts.Scan("not a date"))
+	assert.NotEqual(t, nil, ts.Scan(3.5))
+
+	// per value config
+	denverLoc, err := time.LoadLocation("America/Denver")
+	assert.Equal(t, nil, err)
+	ts = Time{Config: &Config{Location: denverLoc, Options: []ParserOption{PreferMonthFirst(false)}}}
+	assert.Equal(t, nil, ts.Scan("02/01/2013 00:00:00"))
+	assert.Equal(t, "2013-01-02 07:00:00 +0000 UTC", fmt.Sprintf("%v", ts.In(time.UTC)))
+}
+
+func TestTimeValue(t *testing.T) {
+	when := time.Date(2014, 4, 26, 17, 24, 37, 0, time.UTC)
+
+	v, err := Time{Time: when}.Value()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, when, v)
+
+	v, err = Time{Time: when, Config: &Config{Format: "2006-01-02"}}.Value()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2014-04-26", v)
+
+	// package level default
+	DefaultConfig = &Config{Format: time.RFC3339}
+	defer func() { DefaultConfig = &Config{} }()
+	v, err = Time{Time: when}.Value()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2014-04-26T17:24:37Z", v)
+}
+
+func TestNullTime(t *testing.T) {
+	time.Local = time.UTC
+
+	var n NullTime
+	assert.Equal(t, nil, n.Scan(nil))
+	assert.Equal(t, false, n.Valid)
+	v, err := n.Value()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, nil, v)
+
+	assert.Equal(t, nil, n.Scan([]byte("  ")))
+	assert.Equal(t, false, n.Valid)
+
+	assert.Equal(t, nil, n.Scan("oct 7, 1970"))
+	assert.Equal(t, true, n.Valid)
+	assert.Equal(t, "1970-10-07 00:00:00 +0000 UTC", fmt.Sprintf("%v", n.Time.In(time.UTC)))
+	v, err = n.Value()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, n.Time, v)
+
+	assert.NotEqual(t, nil, n.Scan("not a date"))
+}
+
+func TestTimeJSON(t *testing.T) {
+	time.Local = time.UTC
+
+	var payload struct {
+		Sent     Time     `json:"sent"`
+		Received Time     `json:"received"`
+		Due      NullTime `json:"due"`
+	}
+	err := json.Unmarshal([]byte(`{"sent":"Tue, 11 Jul 2017 16:28:13 +0200","received":1499779693000,"due":null}`), &payload)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2017-07-11 14:28:13 +0000 UTC", fmt.Sprintf("%v", payload.Sent.In(time.UTC)))
+	assert.Equal(t, "2017-07-11 13:28:13 +0000 UTC", fmt.Sprintf("%v", payload.Received.In(time.UTC)))
+	assert.Equal(t, false, payload.Due.Valid)
+
+	b, err := json.Marshal(payload)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, `{"sent":"2017-07-11T16:28:13+02:00","received":"2017-07-11T13:28:13Z","due":null}`, string(b))
+
+	assert.NotEqual(t, nil, json.Unmarshal([]byte(`"not a date"`), &payload.Sent))
+	assert.NotEqual(t, nil, json.Unmarshal([]byte(`true`), &payload.Sent))
+	assert.Equal(t, nil, json.Unmarshal([]byte(`""`), &payload.Due))
+	assert.Equal(t, false, payload.Due.Valid)
+
+	// per value config, day first and remembering the layout
+	cfg := &Config{Options: []ParserOption{PreferMonthFirst(false)}, KeepLayout: true}
+	ts := []Time{{Config: cfg}, {Config: cfg}}
+	err = json.Unmarshal([]byte(`["02/01/2020", 1499779693000]`), &ts)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, time.January, ts[0].Month())
+	assert.Equal(t, "02/01/2006", ts[0].Layout.Layout)
+	ts[0].Time = ts[0].AddDate(0, 0, 1)
+	ts[1].Time = ts[1].Add(time.Second)
+	b, err = json.Marshal(ts)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, `["03/01/2020",1499779694000]`, string(b))
+}
+
+func TestTimeText(t *testing.T) {
+	time.Local = time.UTC
+
+	var tt Time
+	assert.Equal(t, nil, tt.UnmarshalText([]byte("2020/01/02")))
+	assert.Equal(t, "2020-01-02 00:00:00 +0000 UTC", fmt.Sprintf("%v", tt.In(time.UTC)))
+	b, err := tt.MarshalText()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2020-01-02T00:00:00Z", string(b))
+
+	tt.Config = &Config{Format: "Jan 2, 2006"}
+	b, err = tt.MarshalText()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "Jan 2, 2020", string(b))
+
+	var n NullTime
+	assert.Equal(t, nil, n.UnmarshalText([]byte("")))
+	assert.Equal(t, false, n.Valid)
+	b, err = n.MarshalText()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "", string(b))
+	assert.Equal(t, nil, n.UnmarshalText([]byte("12 Feb 2006, 19:17")))
+	assert.Equal(t, true, n.Valid)
+	assert.NotEqual(t, nil, n.UnmarshalText([]byte("not a date")))
+}
@@ -0,0 +1,242 @@
+package dateparse
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TimeOfDay is a wall clock time without a date, see ParseTimeOfDay.
+type TimeOfDay struct {
+	Hour       int
+	Minute     int
+	Second     int
+	Nanosecond int
+	// Zone abbreviation given after the time, ie "CET", "" if none
+	Zone string
+	// Offset in seconds east of UTC, when HasOffset ("Z", "+07:00")
+	Offset    int
+	HasOffset bool
+}
+
+// ampmReplacer spells a.m. and p.m. as the tokenizer reads them
+var ampmReplacer = strings.NewReplacer("a.m.", "am", "p.m.", "pm", "A.M.", "AM", "P.M.", "PM")
+
+// ParseTimeOfDay parses a time without a date: "17:30", "5pm",
+// "5:30 p.m.", "0930", "T10:00:00Z", "10:00:00.123 CET", "noon".  The
+// zone, if any, is kept for On to resolve.
+//
+//	c, err := dateparse.ParseTimeOfDay("5:30 p.m.")
+//	t, err := c.On(time.Date(2020, 3, 8, 0, 0, 0, 0, time.UTC), denver)
+func ParseTimeOfDay(timestr string) (TimeOfDay, error) {
+	var c TimeOfDay
+	s, _ := Normalize(strings.TrimSpace(timestr))
+	s = ampmReplacer.Replace(s)
+	if len(s) > 1 && (s[0] == 'T' || s[0] == 't') && s[1] >= '0' && s[1] <= '9' {
+		// the time of an iso 8601 date
+		s = s[1:]
+	}
+	var toks []Token
+	for _, tok := range lex(s, 0, len(s), nil) {
+		if tok.Kind != TokenSeparator || strings.TrimSpace(tok.Text) != "" {
+			toks = append(toks, tok)
+		}
+	}
+	bad := func(i int) (TimeOfDay, error) {
+		if i >= len(toks) {
+			return TimeOfDay{}, fmt.Errorf("parsing time of day %q: too short", timestr)
+		}
+		return TimeOfDay{}, fmt.Errorf("parsing time of day %q: unexpected %q", timestr, toks[i].Text)
+	}
+	sep := func(i int, seps string) bool {
+		return i < len(toks) && toks[i].Kind == TokenSeparator && len(toks[i].Text) == 1 && strings.Contains(seps, toks[i].Text)
+	}
+	number := func(i, digits int) bool {
+		return i < len(toks) && toks[i].Kind == TokenNumber && (digits == 0 || toks[i].Digits == digits)
+	}
+
+	i := 0
+	switch {
+	case i < len(toks) && spokenTimes[strings.ToLower(toks[i].Text)] != "":
+		if strings.ToLower(toks[i].Text) != "midnight" {
+			c.Hour = 12
+		}
+		i++
+	case number(i, 4) || number(i, 6):
+		// 0930, 093015
+		digits := toks[i].Text
+		c.Hour, _ = strconv.Atoi(digits[:2])
+		c.Minute, _ = strconv.Atoi(digits[2:4])
+		if len(digits) == 6 {
+			c.Second, _ = strconv.Atoi(digits[4:])
+		}
+		i++
+	case number(i, 1) || number(i, 2):
+		c.Hour = toks[i].Value
+		i++
+		if sep(i, ":") {
+			if !number(i+1, 2) {
+				return bad(i + 1)
+			}
+			c.Minute = toks[i+1].Value
+			i += 2
+			if sep(i, ":") {
+				if !number(i+1, 2) {
+					return bad(i + 1)
+				}
+				c.Second = toks[i+1].Value
+				i += 2
+			}
+		}
+	default:
+		return bad(i)
+	}
+	if sep(i, ".,") && number(i+1, 0) {
+		frac := toks[i+1].Text
+		if len(frac) > 9 {
+			frac = frac[:9]
+		}
+		ns, _ := strconv.Atoi(frac + strings.Repeat("0", 9-len(frac)))
+		c.Nanosecond = ns
+		i += 2
+	}
+	if i < len(toks) && toks[i].Kind == TokenAMPM {
+		if c.Hour < 1 || c.Hour > 12 {
+			return TimeOfDay{}, fmt.Errorf("parsing time of day %q: hour %d with %s", timestr, c.Hour, toks[i].Text)
+		}
+		c.Hour %= 12
+		if strings.ToLower(toks[i].Text) == "pm" {
+			c.Hour += 12
+		}
+		i++
+	}
+	if c.Hour > 23 || c.Minute > 59 || c.Second > 59 {
+		return TimeOfDay{}, fmt.Errorf("parsing time of day %q: out of range", timestr)
+	}
+	if i < len(toks) && isClockZone(toks[i]) {
+		if toks[i].Text == "Z" {
+			c.HasOffset = true
+		} else {
+			c.Zone = strings.ToUpper(toks[i].Text)
+		}
+		i++
+	}
+	if i < len(toks) && toks[i].Kind == TokenOffset {
+		c.Offset, c.HasOffset = toks[i].Value, true
+		i++
+	}
+	if i < len(toks) {
+		return bad(i)
+	}
+	return c, nil
+}
+
+// isClockZone is true if tok after a clock is a zone, known or an upper
+// case abbreviation for On to reject, ie "XYZ"
+func isClockZone(tok Token) bool {
+	upper := len(tok.Text) >= 3 && len(tok.Text) <= 5 && strings.ToUpper(tok.Text) == tok.Text
+	return tok.Kind == TokenZone || tok.Kind == TokenWord && upper
+}
+
+// On is the time c on the date's year, month and day, in loc (nil is
+// UTC).  A clock with an offset is that instant, shown in loc.  A zone
+// abbreviation is looked up in loc around the date, then in the common
+// abbreviations, failing with ErrZoneAbbrev if unknown or ambiguous.
+// Otherwise the clock is a wall clock in loc: one skipped by a daylight
+// saving change moves forward by the change (02:30 is 03:30), one that
+// happens twice is the first.
+func (c TimeOfDay) On(date time.Time, loc *time.Location) (time.Time, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	y, m, d := date.Date()
+	wall := time.Date(y, m, d, c.Hour, c.Minute, c.Second, c.Nanosecond, time.UTC)
+	if c.HasOffset {
+		return wall.Add(-time.Duration(c.Offset) * time.Second).In(loc), nil
+	}
+	// the offsets either side of the date, to resolve daylight saving
+	offsets := []int{zoneOffset(wall.Add(-12*time.Hour), loc), zoneOffset(wall.Add(12*time.Hour), loc)}
+	if c.Zone != "" {
+		offset, ok := c.zoneOffset(wall, loc)
+		if !ok {
+			return time.Time{}, ErrZoneAbbrev
+		}
+		return wall.Add(-time.Duration(offset) * time.Second).In(loc), nil
+	}
+	var first time.Time
+	for _, offset := range offsets {
+		t := wall.Add(-time.Duration(offset) * time.Second).In(loc)
+		if t.Hour() != c.Hour || t.Minute() != c.Minute || t.Day() != d {
+			continue
+		}
+		if first.IsZero() || t.Before(first) {
+			first = t
+		}
+	}
+	if first.IsZero() {
+		// skipped, read with the offset before the change
+		return wall.Add(-time.Duration(offsets[0]) * time.Second).In(loc), nil
+	}
+	return first, nil
+}
+
+// zoneOffset is the offset of the zone abbreviation c.Zone around wall:
+// as loc names it, UTC, or its single common meaning.
+func (c TimeOfDay) zoneOffset(wall time.Time, loc *time.Location) (int, bool) {
+	if c.Zone == "UTC" || c.Zone == "GMT" {
+		return 0, true
+	}
+	for _, at := range []time.Time{wall.Add(-12 * time.Hour), wall.Add(12 * time.Hour)} {
+		if name, offset := at.In(loc).Zone(); name == c.Zone {
+			return offset, true
+		}
+	}
+	if zones := zoneAbbrevs[c.Zone]; len(zones) == 1 {
+		return zones[0].offset, true
+	}
+	return 0, false
+}
+
+func zoneOffset(t time.Time, loc *time.Location) int {
+	_, offset := t.In(loc).Zone()
+	return offset
+}
+
+// String is the clock as 15:04:05, with any fraction, zone and offset,
+// ie "17:30:00", "10:00:00.123 CET", "10:00:00Z", "10:00:00+02:00".
+func (c TimeOfDay) String() string {
+	s := fmt.Sprintf("%02d:%02d:%02d", c.Hour, c.Minute, c.Second)
+	if c.Nanosecond != 0 {
+		s += strings.TrimRight(fmt.Sprintf(".%09d", c.Nanosecond), "0")
+	}
+	if c.Zone != "" {
+		s += " " + c.Zone
+	}
+	if c.HasOffset {
+		if c.Offset == 0 && c.Zone == "" {
+			return s + "Z"
+		}
+		sign, offset := '+', c.Offset
+		if offset < 0 {
+			sign, offset = '-', -offset
+		}
+		s += fmt.Sprintf("%c%02d:%02d", sign, offset/3600, offset%3600/60)
+	}
+	return s
+}
+
+// MarshalText writes the clock as String does
+func (c TimeOfDay) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText reads any clock ParseTimeOfDay does
+func (c *TimeOfDay) UnmarshalText(text []byte) error {
+	v, err := ParseTimeOfDay(string(text))
+	if err != nil {
+		return err
+	}
+	*c = v
+	return nil
+}
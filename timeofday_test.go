@@ -0,0 +1,115 @@
+package dateparse
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTimeOfDay(t *testing.T) {
+	tests := []struct {
+		in  string
+		out string
+	}{
+		{"17:30", "17:30:00"},
+		{"5pm", "17:00:00"},
+		{"5 PM", "17:00:00"},
+		{"5:30 p.m.", "17:30:00"},
+		{"5:30pm", "17:30:00"},
+		{"12:15 a.m.", "00:15:00"},
+		{"12pm", "12:00:00"},
+		{"0930", "09:30:00"},
+		{"093015", "09:30:15"},
+		{"T10:00:00Z", "10:00:00Z"},
+		{"10:00 CET", "10:00:00 CET"},
+		{"10:00:00.123 UTC", "10:00:00.123 UTC"},
+		{"10:00:00,5", "10:00:00.5"},
+		{"23:59:59.123456789123", "23:59:59.123456789"},
+		{"10:00:00+02:00", "10:00:00+02:00"},
+		{"10:00-0730", "10:00:00-07:30"},
+		{"10:00 PST-08", "10:00:00 PST-08:00"},
+		{" 9:05 ", "09:05:00"},
+		{"noon", "12:00:00"},
+		{"Midnight", "00:00:00"},
+		{"１０：３０", "10:30:00"},
+	}
+	for _, tc := range tests {
+		c, err := ParseTimeOfDay(tc.in)
+		assert.Equal(t, nil, err, tc.in)
+		assert.Equal(t, tc.out, c.String(), tc.in)
+	}
+
+	for _, in := range []string{"", "pm", "25:00", "10:61", "13pm", "0pm", "10:5", "10:00 on", "2020-01-02", "10:13:2014", "10:00 CET CET"} {
+		_, err := ParseTimeOfDay(in)
+		assert.NotEqual(t, nil, err, in)
+	}
+
+	c, err := ParseTimeOfDay("10:00:00.5+01:00")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, TimeOfDay{Hour: 10, Nanosecond: 500000000, Offset: 3600, HasOffset: true}, c)
+}
+
+func TestTimeOfDayOn(t *testing.T) {
+	denver, err := time.LoadLocation("America/Denver")
+	assert.Equal(t, nil, err)
+	date := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		clock string
+		date  time.Time
+		loc   *time.Location
+		out   string
+	}{
+		{"17:30", date(2020, 1, 2), nil, "2020-01-02T17:30:00Z"},
+		{"17:30", date(2020, 1, 2), denver, "2020-01-02T17:30:00-07:00"},
+		{"17:30", date(2020, 7, 2), denver, "2020-07-02T17:30:00-06:00"},
+		// spring forward, 02:00-03:00 doesn't happen
+		{"02:30", date(2020, 3, 8), denver, "2020-03-08T03:30:00-06:00"},
+		{"01:59", date(2020, 3, 8), denver, "2020-03-08T01:59:00-07:00"},
+		{"03:00", date(2020, 3, 8), denver, "2020-03-08T03:00:00-06:00"},
+		// fall back, 01:00-02:00 happens twice
+		{"01:30", date(2020, 11, 1), denver, "2020-11-01T01:30:00-06:00"},
+		{"02:30", date(2020, 11, 1), denver, "2020-11-01T02:30:00-07:00"},
+		// the clock's own offset or zone
+		{"10:00Z", date(2020, 1, 2), denver, "2020-01-02T03:00:00-07:00"},
+		{"10:00+02:00", date(2020, 1, 2), nil, "2020-01-02T08:00:00Z"},
+		{"10:00 MDT", date(2020, 1, 2), denver, "2020-01-02T09:00:00-07:00"},
+		{"10:00 MST", date(2020, 7, 2), denver, "2020-07-02T11:00:00-06:00"},
+		{"10:00 CET", date(2020, 1, 2), nil, "2020-01-02T09:00:00Z"},
+		{"10:00 UTC", date(2020, 1, 2), denver, "2020-01-02T03:00:00-07:00"},
+	}
+	for _, tc := range tests {
+		c, err := ParseTimeOfDay(tc.clock)
+		assert.Equal(t, nil, err, tc.clock)
+		ts, err := c.On(tc.date, tc.loc)
+		assert.Equal(t, nil, err, tc.clock)
+		assert.Equal(t, tc.out, ts.Format(time.RFC3339), "%s on %s", tc.clock, tc.date)
+	}
+
+	// the date's own location doesn't matter, only its calendar day
+	c, _ := ParseTimeOfDay("17:30")
+	ts, err := c.On(time.Date(2020, 1, 2, 23, 0, 0, 0, denver), time.UTC)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "2020-01-02T17:30:00Z", ts.Format(time.RFC3339))
+
+	for _, clock := range []string{"10:00 CST", "10:00 XYZ"} {
+		c, _ := ParseTimeOfDay(clock)
+		_, err := c.On(date(2020, 1, 2), denver)
+		assert.Equal(t, ErrZoneAbbrev, err, clock)
+	}
+}
+
+func TestTimeOfDayJSON(t *testing.T) {
+	var v struct {
+		Opens TimeOfDay `json:"opens"`
+	}
+	assert.Equal(t, nil, json.Unmarshal([]byte(`{"opens":"9:30 a.m."}`), &v))
+	assert.Equal(t, TimeOfDay{Hour: 9, Minute: 30}, v.Opens)
+	b, err := json.Marshal(v)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, `{"opens":"09:30:00"}`, string(b))
+	assert.NotEqual(t, nil, json.Unmarshal([]byte(`{"opens":"later"}`), &v))
+}
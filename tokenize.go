@@ -0,0 +1,325 @@
+package dateparse
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// TokenKind is the kind of a Token.
+type TokenKind uint8
+
+const (
+	// TokenNumber is a run of digits, Digits long
+	TokenNumber TokenKind = iota
+	// TokenMonth is a month name or abbreviation, ie "Jan", "Sept", "march"
+	TokenMonth
+	// TokenWeekday is a weekday name or abbreviation, ie "Mon", "tuesday"
+	TokenWeekday
+	// TokenAMPM is "AM" or "PM", any case
+	TokenAMPM
+	// TokenZone is a zone abbreviation, ie "MST", "CEST", "UTC" or a "Z"
+	// after a time
+	TokenZone
+	// TokenOffset is a zone offset, ie "+0700", "-07:00" or "+01"
+	TokenOffset
+	// TokenSeparator is a run of punctuation and whitespace, or the "T"
+	// between a date and time
+	TokenSeparator
+	// TokenOrdinal is the suffix of an ordinal number, ie "st" in "1st"
+	TokenOrdinal
+	// TokenCJKUnit is a chinese/japanese date or time unit, ie "年", "月"
+	TokenCJKUnit
+	// TokenWord is any other run of letters, ie "at", "of", "Daylight"
+	TokenWord
+)
+
+var tokenKindNames = []string{
+	TokenNumber:    "number",
+	TokenMonth:     "month",
+	TokenWeekday:   "weekday",
+	TokenAMPM:      "ampm",
+	TokenZone:      "zone",
+	TokenOffset:    "offset",
+	TokenSeparator: "separator",
+	TokenOrdinal:   "ordinal",
+	TokenCJKUnit:   "cjk-unit",
+	TokenWord:      "word",
+}
+
+func (k TokenKind) String() string {
+	if int(k) < len(tokenKindNames) {
+		return tokenKindNames[k]
+	}
+	return fmt.Sprintf("TokenKind(%d)", k)
+}
+
+// MarshalText writes the kind as its name, ie "month"
+func (k TokenKind) MarshalText() ([]byte, error) {
+	if int(k) >= len(tokenKindNames) {
+		return nil, fmt.Errorf("unknown token kind %d", k)
+	}
+	return []byte(k.String()), nil
+}
+
+// UnmarshalText reads a kind written by MarshalText
+func (k *TokenKind) UnmarshalText(text []byte) error {
+	for i, name := range tokenKindNames {
+		if name == string(text) {
+			*k = TokenKind(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown token kind %q", text)
+}
+
+// Token is one lexical piece of a date string, Text is datestr[Start:End].
+type Token struct {
+	Kind  TokenKind `json:"kind"`
+	Text  string    `json:"text"`
+	Start int       `json:"start"`
+	End   int       `json:"end"`
+	// Digits in a TokenNumber, ie 4 for "2006"
+	Digits int `json:"digits,omitempty"`
+	// Value of a TokenNumber, the month of a TokenMonth (1-12), the
+	// time.Weekday of a TokenWeekday and the seconds east of UTC of a
+	// TokenOffset.  Numbers that don't fit an int are 0.
+	Value int `json:"value"`
+}
+
+// cjkUnits are the chinese/japanese year, month, day, hour, minute and
+// second units
+const cjkUnits = "年月日时時分秒"
+
+// Tokenize splits a date string into typed tokens the way the parser
+// reads it: numbers, month and weekday names, AM/PM, zone abbreviations,
+// offsets, separators, ordinal suffixes and CJK units.  Every byte of
+// datestr is in exactly one token, so grammars built on the tokens can
+// point back into the input.  Where the parser reads datestr as it is,
+// the fields it found (as Explain reports them) are the number, name,
+// AM/PM, zone and offset tokens, and only the text between them is split
+// lexically, as are strings the parser doesn't read, ie "Jan 2" or
+// "report 2020 Q3".
+//
+//	toks := dateparse.Tokenize("Mon, 2 Jan 2006 15:04:05 -0700")
+//	// weekday "Mon", separator ", ", number "2", separator " ", month "Jan", ...
+func Tokenize(datestr string) []Token {
+	var toks []Token
+	at := 0
+	for _, sp := range parsedFields(datestr) {
+		toks = lex(datestr, at, sp.Start, toks)
+		toks = append(toks, fieldTokens(datestr, sp)...)
+		at = sp.End
+	}
+	return lex(datestr, at, len(datestr), toks)
+}
+
+// parsedFields are the spans of the fields the parser finds in datestr,
+// nil if it doesn't read datestr as it is (ie it fails, or rewrites it).
+func parsedFields(datestr string) []Span {
+	p, err := parseTime(datestr, nil)
+	if err != nil {
+		return nil
+	}
+	if _, err = p.parse(); err != nil {
+		return nil
+	}
+	l := p.layout(datestr)
+	if normalized, _ := Normalize(datestr); l.Kind != LayoutGo || p.datestr != normalized {
+		return nil
+	}
+	return p.spans(datestr, l)
+}
+
+// fieldTokens are the tokens of a field the parser found
+func fieldTokens(datestr string, sp Span) []Token {
+	var toks []Token
+	if sp.Field == "fraction" && (sp.Text[0] == '.' || sp.Text[0] == ',') {
+		// the separator is part of a go layout's fraction
+		toks = append(toks, Token{Kind: TokenSeparator, Start: sp.Start, End: sp.Start + 1, Text: sp.Text[:1]})
+		sp.Start++
+		sp.Text = sp.Text[1:]
+	}
+	tok := Token{Start: sp.Start, End: sp.End, Text: sp.Text}
+	digits, _ := Normalize(sp.Text)
+	switch {
+	case isDigits(digits):
+		tok.Kind, tok.Digits = TokenNumber, utf8.RuneCountInString(sp.Text)
+		tok.Value, _ = strconv.Atoi(digits)
+	case sp.Field == "ampm":
+		tok.Kind = TokenAMPM
+	case sp.Field == "zone" || sp.Text == "Z":
+		tok.Kind = TokenZone
+	case sp.Field == "offset":
+		tok.Kind, tok.Value = TokenOffset, offsetSeconds(digits)
+	default:
+		// month and weekday names
+		tok = letterToken(datestr, sp.Start, sp.End, nil)
+		tok.Start, tok.End, tok.Text = sp.Start, sp.End, sp.Text
+	}
+	return append(toks, tok)
+}
+
+// lex splits datestr[start:end] into tokens by their text alone, after the
+// tokens toks of datestr[:start].  The parser uses it on rewritten and
+// partial input, which it can't parse to find the fields of.
+func lex(datestr string, start, end int, toks []Token) []Token {
+	timeSeen := false
+	for _, tok := range toks {
+		if tok.Kind == TokenSeparator && strings.Contains(tok.Text, ":") {
+			timeSeen = true
+		}
+	}
+	s := datestr[:end]
+	for i := start; i < end; {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		start := i
+		var tok Token
+		switch {
+		case unicode.IsDigit(r):
+			i = runEnd(s, i, unicode.IsDigit)
+			tok = Token{Kind: TokenNumber, Digits: utf8.RuneCountInString(s[start:i])}
+			digits, _ := Normalize(s[start:i])
+			tok.Value, _ = strconv.Atoi(digits)
+		case strings.ContainsRune(cjkUnits, r):
+			i += size
+			tok = Token{Kind: TokenCJKUnit}
+		case unicode.IsLetter(r):
+			i = runEnd(s, i, func(r rune) bool {
+				return unicode.IsLetter(r) && !strings.ContainsRune(cjkUnits, r)
+			})
+			// the whole datestr, to see the digit after a "T"
+			tok = letterToken(datestr, start, i, toks)
+		case (r == '+' || r == '-') && isOffsetAt(s, i, timeSeen, toks):
+			i = offsetEnd(s, i)
+			tok = Token{Kind: TokenOffset, Value: offsetSeconds(s[start:i])}
+		default:
+			for i < len(s) {
+				r, size := utf8.DecodeRuneInString(s[i:])
+				if unicode.IsLetter(r) || unicode.IsDigit(r) || i > start && (r == '+' || r == '-') && isOffsetAt(s, i, timeSeen, toks) {
+					break
+				}
+				i += size
+			}
+			tok = Token{Kind: TokenSeparator}
+			if strings.Contains(s[start:i], ":") {
+				timeSeen = true
+			}
+		}
+		tok.Start, tok.End, tok.Text = start, i, s[start:i]
+		toks = append(toks, tok)
+	}
+	return toks
+}
+
+// runEnd is the offset after the run of runes from i matching fn
+func runEnd(s string, i int, fn func(rune) bool) int {
+	for i < len(s) {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if !fn(r) {
+			break
+		}
+		i += size
+	}
+	return i
+}
+
+// letterToken classifies the run of letters s[start:end], toks are the
+// tokens before it.
+func letterToken(s string, start, end int, toks []Token) Token {
+	word := s[start:end]
+	lower := strings.ToLower(word)
+	afterNumber := len(toks) > 0 && toks[len(toks)-1].Kind == TokenNumber
+	beforeDigit := end < len(s) && s[end] >= '0' && s[end] <= '9'
+	switch {
+	case afterNumber && (lower == "st" || lower == "nd" || lower == "rd" || lower == "th"):
+		return Token{Kind: TokenOrdinal}
+	case lower == "t" && afterNumber && beforeDigit:
+		// 2006-01-02T15:04:05
+		return Token{Kind: TokenSeparator}
+	case lower == "am" || lower == "pm":
+		return Token{Kind: TokenAMPM}
+	case word == "Z" && afterNumber:
+		return Token{Kind: TokenZone}
+	}
+	for i, month := range months {
+		if lower == month || lower == month[:3] || (lower == "sept" && i == 8) {
+			return Token{Kind: TokenMonth, Value: i + 1}
+		}
+	}
+	for i, day := range days {
+		if lower == day {
+			// days starts on monday
+			return Token{Kind: TokenWeekday, Value: int(time.Weekday((i + 1) % 7))}
+		}
+	}
+	if lower == "utc" || lower == "gmt" || isZoneAbbrev(word) {
+		return Token{Kind: TokenZone}
+	}
+	return Token{Kind: TokenWord}
+}
+
+// isZoneAbbrev is true for the upper case zone abbreviations in
+// zoneAbbrevs, not other capitals such as a log level, ie "INFO"
+func isZoneAbbrev(word string) bool {
+	_, ok := zoneAbbrevs[word]
+	return ok
+}
+
+// isOffsetAt is true if the sign at s[i] starts a zone offset: +hh,
+// +hhmm or +hh:mm after a time or zone, or +hh:mm anywhere (ie
+// "2020-07-20+08:00").
+func isOffsetAt(s string, i int, timeSeen bool, toks []Token) bool {
+	end := offsetEnd(s, i)
+	n := end - i - 1
+	if n != 2 && n != 4 && n != 5 {
+		return false
+	}
+	if n == 5 {
+		return true
+	}
+	if len(toks) > 0 && toks[len(toks)-1].Kind == TokenZone {
+		return true
+	}
+	return timeSeen
+}
+
+// offsetEnd is the end of the digits (and optional hh:mm colon) after the
+// sign at s[i].
+func offsetEnd(s string, i int) int {
+	j := i + 1
+	for j < len(s) && s[j] >= '0' && s[j] <= '9' {
+		j++
+	}
+	if j-i-1 == 2 && j+3 <= len(s) && s[j] == ':' && isDigits(s[j+1:j+3]) && (j+3 == len(s) || !isDigits(s[j+3:j+4])) {
+		return j + 3
+	}
+	return j
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// offsetSeconds is the seconds east of UTC of an offset token
+func offsetSeconds(offset string) int {
+	digits := strings.Replace(offset[1:], ":", "", 1)
+	h, _ := strconv.Atoi(digits[:2])
+	m := 0
+	if len(digits) == 4 {
+		m, _ = strconv.Atoi(digits[2:])
+	}
+	secs := h*3600 + m*60
+	if offset[0] == '-' {
+		secs = -secs
+	}
+	return secs
+}
@@ -0,0 +1,72 @@
+package dateparse
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// tokenString is a compact form of tokens for tests, ie
+// `number(4)"2006" separator"-"`
+func tokenString(toks []Token) string {
+	var parts []string
+	for _, tok := range toks {
+		switch tok.Kind {
+		case TokenNumber:
+			parts = append(parts, fmt.Sprintf("number(%d)%q", tok.Digits, tok.Text))
+		case TokenMonth, TokenWeekday, TokenOffset:
+			parts = append(parts, fmt.Sprintf("%s(%d)%q", tok.Kind, tok.Value, tok.Text))
+		default:
+			parts = append(parts, fmt.Sprintf("%s%q", tok.Kind, tok.Text))
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+func TestTokenize(t *testing.T) {
+	for _, tc := range []struct{ in, out string }{
+		{"2006-01-02", `number(4)"2006" separator"-" number(2)"01" separator"-" number(2)"02"`},
+		{"2009-08-12T22:15:09.99Z", `number(4)"2009" separator"-" number(2)"08" separator"-" number(2)"12" separator"T" number(2)"22" separator":" number(2)"15" separator":" number(2)"09" separator"." number(2)"99" zone"Z"`},
+		{"Mon, 2 Jan 2006 15:04:05 -0700", `weekday(1)"Mon" separator", " number(1)"2" separator" " month(1)"Jan" separator" " number(4)"2006" separator" " number(2)"15" separator":" number(2)"04" separator":" number(2)"05" separator" " offset(-25200)"-0700"`},
+		{"2020-07-20+08:00", `number(4)"2020" separator"-" number(2)"07" separator"-" number(2)"20" offset(28800)"+08:00"`},
+		{"Fri Jul 03 2015 18:04:07 GMT+0100 (GMT Daylight Time)", `weekday(5)"Fri" separator" " month(7)"Jul" separator" " number(2)"03" separator" " number(4)"2015" separator" " number(2)"18" separator":" number(2)"04" separator":" number(2)"07" separator" " zone"GMT" offset(3600)"+0100" separator" (" zone"GMT" separator" " word"Daylight" separator" " word"Time" separator")"`},
+		{"September 17th, 2012 at 5:00pm UTC-05", `month(9)"September" separator" " number(2)"17" ordinal"th" separator", " number(4)"2012" separator" " word"at" separator" " number(1)"5" separator":" number(2)"00" ampm"pm" separator" " zone"UTC" offset(-18000)"-05"`},
+		{"Sept. 7, '70", `month(9)"Sept" separator". " number(1)"7" separator", '" number(2)"70"`},
+		{"2014年04月08日", `number(4)"2014" cjk-unit"年" number(2)"04" cjk-unit"月" number(2)"08" cjk-unit"日"`},
+		{"12 Feb 2006, 19:17 +01", `number(2)"12" separator" " month(2)"Feb" separator" " number(4)"2006" separator", " number(2)"19" separator":" number(2)"17" separator" " offset(3600)"+01"`},
+		// the parser reads an unknown zone after a time, the lexer alone
+		// doesn't
+		{"2006-01-02 15:04:05 NPT", `number(4)"2006" separator"-" number(2)"01" separator"-" number(2)"02" separator" " number(2)"15" separator":" number(2)"04" separator":" number(2)"05" separator" " zone"NPT"`},
+		{"NPT", `word"NPT"`},
+		// capitals are not zones unless they are known abbreviations
+		{"INFO 2020-01-02 WARN", `word"INFO" separator" " number(4)"2020" separator"-" number(2)"01" separator"-" number(2)"02" separator" " word"WARN"`},
+		{"DEBUG 10:00 CET", `word"DEBUG" separator" " number(2)"10" separator":" number(2)"00" separator" " zone"CET"`},
+		{"1332151919", `number(10)"1332151919"`},
+		{"", ``},
+	} {
+		toks := Tokenize(tc.in)
+		assert.Equal(t, tc.out, tokenString(toks), tc.in)
+		// every byte is in a token
+		end := 0
+		for _, tok := range toks {
+			assert.Equal(t, end, tok.Start, tc.in)
+			assert.Equal(t, tc.in[tok.Start:tok.End], tok.Text, tc.in)
+			end = tok.End
+		}
+		assert.Equal(t, len(tc.in), end, tc.in)
+	}
+
+	toks := Tokenize("Tuesday 5th")
+	assert.Equal(t, 2, toks[0].Value)
+	assert.Equal(t, 5, toks[2].Value)
+
+	b, err := json.Marshal(Tokenize("Jan"))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, `[{"kind":"month","text":"Jan","start":0,"end":3,"value":1}]`, string(b))
+	var back []Token
+	assert.Equal(t, nil, json.Unmarshal(b, &back))
+	assert.Equal(t, TokenMonth, back[0].Kind)
+}
@@ -0,0 +1,235 @@
+package dateparse
+
+import (
+	"fmt"
+	"time"
+	"unicode/utf8"
+)
+
+var dateStateNames = []string{
+	"dateStart",
+	"dateDigit",
+	"dateDigitSt",
+	"dateYearDash",
+	"dateYearDashAlphaDash",
+	"dateYearDashDash",
+	"dateYearDashDashWs",
+	"dateYearDashDashT",
+	"dateYearDashDashOffset",
+	"dateDigitDash",
+	"dateDigitDashAlpha",
+	"dateDigitDashAlphaDash",
+	"dateDigitDot",
+	"dateDigitDotDot",
+	"dateDigitSlash",
+	"dateDigitYearSlash",
+	"dateDigitSlashAlpha",
+	"dateDigitColon",
+	"dateDigitChineseYear",
+	"dateDigitChineseYearWs",
+	"dateDigitWs",
+	"dateDigitWsMoYear",
+	"dateDigitWsMolong",
+	"dateAlpha",
+	"dateAlphaWs",
+	"dateAlphaWsDigit",
+	"dateAlphaWsDigitMore",
+	"dateAlphaWsDigitMoreWs",
+	"dateAlphaWsDigitMoreWsYear",
+	"dateAlphaWsMonth",
+	"dateAlphaWsDigitYearmaybe",
+	"dateAlphaWsMonthMore",
+	"dateAlphaWsMonthSuffix",
+	"dateAlphaWsMore",
+	"dateAlphaWsAtTime",
+	"dateAlphaWsAlpha",
+	"dateAlphaWsAlphaYearmaybe",
+	"dateAlphaPeriodWsDigit",
+	"dateWeekdayComma",
+	"dateWeekdayAbbrevComma",
+}
+
+var timeStateNames = []string{
+	"timeIgnore",
+	"timeStart",
+	"timeWs",
+	"timeWsAlpha",
+	"timeWsAlphaWs",
+	"timeWsAlphaZoneOffset",
+	"timeWsAlphaZoneOffsetWs",
+	"timeWsAlphaZoneOffsetWsYear",
+	"timeWsAlphaZoneOffsetWsExtra",
+	"timeWsAMPMMaybe",
+	"timeWsAMPM",
+	"timeWsOffset",
+	"timeWsOffsetWs",
+	"timeWsOffsetColonAlpha",
+	"timeWsOffsetColon",
+	"timeWsYear",
+	"timeOffset",
+	"timeOffsetColon",
+	"timeAlpha",
+	"timePeriod",
+	"timePeriodOffset",
+	"timePeriodOffsetColon",
+	"timePeriodOffsetColonWs",
+	"timePeriodWs",
+	"timePeriodWsAlpha",
+	"timePeriodWsOffset",
+	"timePeriodWsOffsetWs",
+	"timePeriodWsOffsetWsAlpha",
+	"timePeriodWsOffsetColon",
+	"timePeriodWsOffsetColonAlpha",
+	"timeZ",
+	"timeZDigit",
+}
+
+func (s dateState) String() string {
+	if int(s) < len(dateStateNames) {
+		return dateStateNames[s]
+	}
+	return fmt.Sprintf("dateState(%d)", s)
+}
+
+func (s timeState) String() string {
+	if int(s) < len(timeStateNames) {
+		return timeStateNames[s]
+	}
+	return fmt.Sprintf("timeState(%d)", s)
+}
+
+// TraceKind is the kind of a TraceEvent.
+type TraceKind uint8
+
+const (
+	// TraceTransition the date or time state changed after reading Rune
+	TraceTransition TraceKind = iota
+	// TraceRestart the input was rewritten (ie weekday prefix dropped, or
+	// "sept." shortened) and parsing started over on Input
+	TraceRestart
+	// TraceSet part of the layout was written, Layout at Offset
+	TraceSet
+	// TraceRetry an ambiguous date was out of range in one month/day
+	// order, and parsing starts over on Input in the other.  Offset and
+	// Rune are zero.
+	TraceRetry
+)
+
+var traceKindNames = []string{
+	TraceTransition: "transition",
+	TraceRestart:    "restart",
+	TraceSet:        "set",
+	TraceRetry:      "retry",
+}
+
+func (k TraceKind) String() string {
+	if int(k) < len(traceKindNames) {
+		return traceKindNames[k]
+	}
+	return fmt.Sprintf("TraceKind(%d)", k)
+}
+
+// TraceEvent is one step the parser's state machine took.
+type TraceEvent struct {
+	Kind TraceKind
+	// Input being parsed, which changes after a restart
+	Input string
+	// Offset of Rune in Input
+	Offset int
+	Rune   rune
+	// DateState and TimeState are the state names after the event, ie
+	// "dateDigitSlash"
+	DateState string
+	TimeState string
+	// Layout written by a TraceSet, ie "2006"
+	Layout string
+}
+
+// WithTrace is an option calling fn with each step the parser takes:
+// state transitions, layout writes, restarts on rewritten input and
+// month/day swap retries.  It is for debugging, see Explain for the same
+// steps collected.
+//
+//	t, err := dateparse.ParseAny("3/1/2014", dateparse.WithTrace(func(ev dateparse.TraceEvent) {
+//		log.Printf("%s %d %q %s %s", ev.Kind, ev.Offset, ev.Rune, ev.DateState, ev.TimeState)
+//	}))
+func WithTrace(fn func(TraceEvent)) ParserOption {
+	return func(p *parser) error {
+		p.trace = fn
+		return nil
+	}
+}
+
+func (p *parser) event(kind TraceKind, input string, offset int, r rune) {
+	p.trace(TraceEvent{
+		Kind:      kind,
+		Input:     input,
+		Offset:    offset,
+		Rune:      r,
+		DateState: p.stateDate.String(),
+		TimeState: p.stateTime.String(),
+	})
+}
+
+// traceSet reports val written to the layout at start.
+func (p *parser) traceSet(start int, val string) {
+	p.traceStates()
+	r, _ := utf8.DecodeRuneInString(p.datestr[start:])
+	p.trace(TraceEvent{
+		Kind:      TraceSet,
+		Input:     p.datestr,
+		Offset:    start,
+		Rune:      r,
+		DateState: p.stateDate.String(),
+		TimeState: p.stateTime.String(),
+		Layout:    val,
+	})
+}
+
+// traceRune is called before each rune is read, reporting any state
+// change the previous rune made.
+func (p *parser) traceRune(i int, r rune) {
+	if p.trace == nil {
+		return
+	}
+	p.traceStates()
+	p.tracei, p.tracer = i, r
+}
+
+// traceStates reports a state change since the last call.
+func (p *parser) traceStates() {
+	if p.trace == nil || p.stateDate == p.tracedDate && p.stateTime == p.tracedTime {
+		return
+	}
+	p.tracedDate, p.tracedTime = p.stateDate, p.stateTime
+	p.event(TraceTransition, p.datestr, p.tracei, p.tracer)
+}
+
+// restart parsing on a rewritten datestr.
+func (p *parser) restart(datestr string, loc *time.Location, opts ...ParserOption) (*parser, error) {
+	if p.trace != nil {
+		p.traceStates()
+		p.event(TraceRestart, datestr, p.tracei, p.tracer)
+	}
+	return parseTime(datestr, loc, append(opts[:len(opts):len(opts)], fromParser(p))...)
+}
+
+// retry parsing datestr with the month/day order swapped.
+func (p *parser) retry(datestr string, loc *time.Location, opts ...ParserOption) (*parser, error) {
+	if p.trace != nil {
+		p.traceStates()
+		p.event(TraceRetry, datestr, 0, 0)
+	}
+	// turn off the retry to avoid endless recursion
+	opts = append(opts[:len(opts):len(opts)], PreferMonthFirst(!p.preferMonthFirst), RetryAmbiguousDateWithSwap(false), fromParser(p))
+	return parseTime(datestr, loc, opts...)
+}
+
+// fromParser gives the parser of a restart or retry the original input,
+// its normalization offsets and what a Lenient clean of it ignored.
+func fromParser(from *parser) ParserOption {
+	return func(p *parser) error {
+		p.input, p.offsets, p.ignored = from.input, from.offsets, from.ignored
+		return nil
+	}
+}